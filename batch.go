@@ -0,0 +1,143 @@
+package bricklinkapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultConcurrency is the number of concurrent requests batch helpers
+// issue if not configured otherwise
+const defaultConcurrency = 4
+
+// MultiError collects the errors of an operation consisting of several
+// independent steps, e.g. the requests of a batch helper.
+type MultiError []error
+
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%v errors occurred: %v", len(m), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the collected errors, so errors.Is and errors.As match any
+// of them, e.g. ErrUnauthorized for a batch run with invalid credentials.
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
+// errOrNil returns nil for an empty MultiError, so it can be returned as error
+func (m MultiError) errOrNil() error {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// BatchOption configures a batch helper.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	concurrency int
+	failFast    bool
+
+	// sem is the semaphore shared by all batch helpers of a handler
+	sem chan struct{}
+}
+
+// WithFailFast makes a batch helper abort on the first failed request and
+// return its error, instead of collecting the errors of all requests. E.g.
+// after a 401 all remaining requests would fail as well.
+func WithFailFast() BatchOption {
+	return func(o *batchOptions) {
+		o.failFast = true
+	}
+}
+
+// batchOptions applies batch options over the defaults of the handler
+func (bl Bricklink) batchOptions(opts []BatchOption) batchOptions {
+	o := batchOptions{
+		concurrency: defaultConcurrency,
+		sem:         bl.sem,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// runBatch calls fn for every index in [0, n) using up to o.concurrency
+// goroutines. It returns the error of each index and the first error that
+// occurred. With o.failFast set, the context passed to fn is cancelled on
+// the first error and the remaining indexes are not run; their error is set
+// to the context error.
+func runBatch(ctx context.Context, n int, o batchOptions, fn func(ctx context.Context, i int) error) (errs []error, first error) {
+	concurrency := o.concurrency
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs = make([]error, n)
+	indexes := make(chan int)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				err := runLimited(ctx, o.sem, i, fn)
+				errs[i] = err
+				if err == nil {
+					continue
+				}
+
+				mu.Lock()
+				if first == nil {
+					first = err
+					if o.failFast {
+						cancel()
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for ; i < n; i++ {
+				errs[i] = ctx.Err()
+			}
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs, first
+}
+
+// runLimited calls fn after acquiring a slot of sem, if set
+func runLimited(ctx context.Context, sem chan struct{}, i int, fn func(ctx context.Context, i int) error) error {
+	if sem != nil {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fn(ctx, i)
+}
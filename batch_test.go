@@ -0,0 +1,57 @@
+package bricklinkapi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunBatch(t *testing.T) {
+	errFoo := errors.New("foo")
+
+	testCases := []struct {
+		desc     string
+		failFast bool
+		maxCalls int32
+	}{
+		{desc: "testing collect all", failFast: false, maxCalls: 100},
+		{desc: "testing fail fast", failFast: true, maxCalls: 2},
+	}
+	for _, tc := range testCases {
+		var calls int32
+		errs, first := runBatch(context.Background(), 100, batchOptions{concurrency: 1, failFast: tc.failFast}, func(ctx context.Context, i int) error {
+			atomic.AddInt32(&calls, 1)
+			if i == 0 {
+				return errFoo
+			}
+			return nil
+		})
+
+		if first != errFoo || errs[0] != errFoo {
+			t.Errorf("\n%v, want first error: %v, got: %v\n", tc.desc, errFoo, first)
+		}
+		if calls > tc.maxCalls || (!tc.failFast && calls != tc.maxCalls) {
+			t.Errorf("\n%v, unexpected number of calls: %v\n", tc.desc, calls)
+		}
+		if tc.failFast && errs[99] == nil {
+			t.Errorf("\n%v, skipped indexes should report the context error\n", tc.desc)
+		}
+	}
+}
+
+func TestMultiErrorUnwrap(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/items/PART/3001": `{"meta":{"code":200},"data":{"no":"3001","type":"PART"}}`,
+		"/items/PART/3002": `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`,
+	}
+
+	_, err := bl.GetItemsBatch(context.Background(), []Item{{Type: "PART", No: "3001"}, {Type: "PART", No: "3002"}})
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) {
+		t.Errorf("\nwant error matching ErrNotFound only, got: %v\n", err)
+	}
+	if CodeOf(err) != 404 {
+		t.Errorf("\nwant code: 404, got: %v\n", CodeOf(err))
+	}
+}
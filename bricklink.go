@@ -1,20 +1,34 @@
 package bricklinkapi
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	brickLinkAPIBaseURL  = "https://api.bricklink.com/api/store/v1"
+	brickLinkAPIHost     = "https://api.bricklink.com"
+	brickLinkAPIPath     = "/api/store/v1"
+	brickLinkAPIBaseURL  = brickLinkAPIHost + brickLinkAPIPath
 	oauthVersion         = "1.0"
 	oauthSignatureMethod = "HMAC-SHA1"
+
+	// requestTimeout is the maximum duration of a single request
+	requestTimeout = time.Second * 30
 )
 
 var (
-	itemTypes = []string{"MINIFIG", "PART", "SET", "BOOK", "GEAR", "CATALOG", "INSTRUCTION", "UNSORTED_LOT", "ORIGINAL_BOX"}
+	itemTypes          = []string{"MINIFIG", "PART", "SET", "BOOK", "GEAR", "CATALOG", "INSTRUCTION", "UNSORTED_LOT", "ORIGINAL_BOX"}
+	coloredItemTypes   = []string{"PART", "MINIFIG", "GEAR"}
+	orderStatuses      = []string{"PENDING", "UPDATED", "PROCESSING", "READY", "PAID", "PACKED", "SHIPPED", "RECEIVED", "COMPLETED", "OCR", "NPB", "NPX", "NRS", "NSS", "CANCELLED", "PURGED"}
+	paymentStatuses    = []string{"None", "Sent", "Received", "Clearing", "Returned", "Bounced", "Completed"}
+	orderDirections    = []string{"in", "out"}
+	completenessValues = []string{"C", "B", "S"}
 )
 
 // Bricklink is the main handler for the Bricklink API requests
@@ -24,30 +38,95 @@ type Bricklink struct {
 	Token          string
 	TokenSecret    string
 	request        RequestHandler
+
+	language     string
+	interceptors []func(*OutgoingRequest)
+	baseURL      string
+	basePath     string
+	baseCtx      context.Context
+	cache        *cache
+
+	priceGuideMaxAge time.Duration
+	flight           *flightGroup
+
+	unmarshal UnmarshalFunc
+
+	retries      int
+	backoff      time.Duration
+	verifyWrites bool
+
+	normalizeText  bool
+	skipValidation bool
+	strictMeta     bool
+
+	// sleep replaces the backoff timer if set, so tests don't have to wait
+	sleep func(ctx context.Context, d time.Duration) error
+
+	limiter *rateLimiter
+	sem     chan struct{}
+
+	logger        Logger
+	logSignatures bool
+	slowThreshold time.Duration
+
+	setVariantSuffix bool
+
+	defaultRegion   string
+	defaultCurrency string
+
+	noAutoPaginate bool
+
+	readTimeout  time.Duration
+	writeTimeout time.Duration
 }
 
-// New returns a Bricklink handler ready to use
-func New(consumerKey, consumerSecret, token, tokenSecret string) *Bricklink {
+// New returns a Bricklink handler ready to use. Options can be passed to
+// change the default behaviour of the handler.
+func New(consumerKey, consumerSecret, token, tokenSecret string, opts ...Option) *Bricklink {
 	bl := &Bricklink{
 		ConsumerKey:    consumerKey,
 		ConsumerSecret: consumerSecret,
 		Token:          token,
 		TokenSecret:    tokenSecret,
-		request: &request{
-			consumerKey:    consumerKey,
-			consumerSecret: consumerSecret,
-			token:          token,
-			tokenSecret:    tokenSecret,
-		},
 	}
 
+	for _, opt := range opts {
+		opt(bl)
+	}
+	if bl.cache != nil && !bl.cache.enabled() {
+		bl.cache = nil
+	}
+
+	r := &request{
+		consumerKey:    consumerKey,
+		consumerSecret: consumerSecret,
+		token:          token,
+		tokenSecret:    tokenSecret,
+		language:       bl.language,
+		interceptors:   bl.interceptors,
+	}
+	if bl.logSignatures {
+		r.signatureLogger = bl.logger
+	}
+	if bl.baseURL != "" || bl.basePath != "" {
+		r.baseURL = joinBaseURL(bl.baseURL, bl.basePath)
+	}
+	bl.request = r
+
 	return bl
 }
 
 // GetItem issues a GET request to the Bricklink API and querys for the specified item.
 func (bl Bricklink) GetItem(itemType, itemNumber string) (response string, err error) {
+	body, err := bl.GetItemBytes(itemType, itemNumber)
+	return string(body), err
+}
+
+// GetItemBytes is like GetItem but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetItemBytes(itemType, itemNumber string) (response []byte, err error) {
 	// validate itemType
-	err = validateParam(itemType, itemTypes)
+	err = bl.validParam(itemType, itemTypes)
 	if err != nil {
 		return response, err
 	}
@@ -56,22 +135,33 @@ func (bl Bricklink) GetItem(itemType, itemNumber string) (response string, err e
 	if itemNumber == "" {
 		return response, errors.New("itemNumber is not specified")
 	}
+	itemNumber = bl.itemNumber(itemType, itemNumber)
 
 	// build uri
 	uri := "/items/" + itemType + "/" + itemNumber
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetItemImage issues a GET request to the Bricklink API and querys for the specified item image.
+// Colors only apply to PART, MINIFIG and GEAR items; for all other types the
+// colorID is replaced by 0 (logged as warning if a logger is set), as BrickLink
+// has no colored images of them.
 func (bl Bricklink) GetItemImage(itemType, itemNumber string, colorID int) (response string, err error) {
+	body, err := bl.GetItemImageBytes(itemType, itemNumber, colorID)
+	return string(body), err
+}
+
+// GetItemImageBytes is like GetItemImage but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetItemImageBytes(itemType, itemNumber string, colorID int) (response []byte, err error) {
 	// validate itemType
-	err = validateParam(itemType, itemTypes)
+	err = bl.validParam(itemType, itemTypes)
 	if err != nil {
 		return response, err
 	}
@@ -80,22 +170,38 @@ func (bl Bricklink) GetItemImage(itemType, itemNumber string, colorID int) (resp
 	if itemNumber == "" {
 		return response, errors.New("itemNumber is not specified")
 	}
+	itemNumber = bl.itemNumber(itemType, itemNumber)
+
+	// validate colorID
+	if colorID != 0 && !stringInSlice(itemType, coloredItemTypes) {
+		if bl.logger != nil {
+			bl.logger.Printf("bricklinkapi: %v items have no colors, requesting image of %v with color 0 instead of %v", itemType, itemNumber, colorID)
+		}
+		colorID = 0
+	}
 
 	// build uri
 	uri := "/items/" + itemType + "/" + itemNumber + "/images/" + strconv.Itoa(colorID)
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetItemPrice issues a GET request to the Bricklink API and querys for the price of an item.
 func (bl Bricklink) GetItemPrice(itemType, itemNumber string, params map[string]string) (response string, err error) {
+	body, err := bl.GetItemPriceBytes(itemType, itemNumber, params)
+	return string(body), err
+}
+
+// GetItemPriceBytes is like GetItemPrice but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetItemPriceBytes(itemType, itemNumber string, params map[string]string) (response []byte, err error) {
 	// validate itemType
-	err = validateParam(itemType, itemTypes)
+	err = bl.validParam(itemType, itemTypes)
 	if err != nil {
 		return response, err
 	}
@@ -104,93 +210,279 @@ func (bl Bricklink) GetItemPrice(itemType, itemNumber string, params map[string]
 	if itemNumber == "" {
 		return response, errors.New("itemNumber is not specified")
 	}
+	itemNumber = bl.itemNumber(itemType, itemNumber)
 
 	// build uri
-	uri := "/items/" + itemType + "/" + itemNumber + "/price"
-
-	// validate and build params
-	if len(params) != 0 {
-		var paramString string
-		for k, v := range params {
-			if paramString != "" {
-				paramString += "&"
-			}
-			paramString += k + "=" + v
-		}
-		uri += "?" + paramString
-	}
+	uri := buildURI("/items/"+itemType+"/"+itemNumber+"/price", bl.priceDefaults(params))
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetColorList issues a GET request to the Bricklink API and querys for a list of all colors.
 func (bl Bricklink) GetColorList() (response string, err error) {
+	body, err := bl.GetColorListBytes()
+	return string(body), err
+}
+
+// GetColorListBytes is like GetColorList but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetColorListBytes() (response []byte, err error) {
 	// build uri
 	uri := "/colors"
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetColor issues a GET request to the Bricklink API and querys for the specified color.
 func (bl Bricklink) GetColor(colorID int) (response string, err error) {
+	body, err := bl.GetColorBytes(colorID)
+	return string(body), err
+}
+
+// GetColorBytes is like GetColor but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetColorBytes(colorID int) (response []byte, err error) {
 	// build uri
 	uri := "/colors/" + strconv.Itoa(colorID)
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetCategoryList issues a GET request to the Bricklink API and querys for a list of all categories.
 func (bl Bricklink) GetCategoryList() (response string, err error) {
+	body, err := bl.GetCategoryListBytes()
+	return string(body), err
+}
+
+// GetCategoryListBytes is like GetCategoryList but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetCategoryListBytes() (response []byte, err error) {
 	// build uri
 	uri := "/categories"
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetCategory issues a GET request to the Bricklink API and querys for a specified category.
 func (bl Bricklink) GetCategory(categoryID int) (response string, err error) {
+	body, err := bl.GetCategoryBytes(categoryID)
+	return string(body), err
+}
+
+// GetCategoryBytes is like GetCategory but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetCategoryBytes(categoryID int) (response []byte, err error) {
 	// build uri
 	uri := "/categories/" + strconv.Itoa(categoryID)
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetInventories issues a GET request to the Bricklink API and querys for user Inventories.
 func (bl Bricklink) GetInventories(categoryID int) (response string, err error) {
+	body, err := bl.GetInventoriesBytes(categoryID)
+	return string(body), err
+}
+
+// GetInventoriesBytes is like GetInventories but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetInventoriesBytes(categoryID int) (response []byte, err error) {
 	// build uri
 	uri := "/inventories/" + strconv.Itoa(categoryID)
 
-	body, err := bl.request.Request("GET", uri)
+	body, err := bl.send(bl.context(), "GET", uri, nil)
 	if err != nil {
 		return response, err
 	}
 
-	return string(body), nil
+	return body, nil
+}
+
+// context returns the context used by methods which don't take a context
+// parameter. It is the base context if one is set, context.Background otherwise.
+func (bl Bricklink) context() context.Context {
+	if bl.baseCtx != nil {
+		return bl.baseCtx
+	}
+	return context.Background()
+}
+
+// send issues the request through the request handler. GET requests failing
+// with a transport error are retried as configured by WithRetry. Writes are
+// never retried here, as the write may have been applied.
+func (bl Bricklink) send(ctx context.Context, method, uri string, payload []byte) (body []byte, err error) {
+	for attempt := 0; ; attempt++ {
+		body, err = bl.sendOnce(ctx, method, uri, payload)
+		if err == errEmptyBody {
+			// only worth another attempt if retries are enabled,
+			// otherwise the empty body is returned as is
+			err = nil
+			if bl.retries > 0 {
+				err = &transportError{errEmptyBody}
+			}
+		}
+		if method != "GET" || attempt >= bl.retries || !retryable(ctx, err) {
+			return body, err
+		}
+
+		err = bl.wait(ctx, attempt)
+		if err != nil {
+			return body, err
+		}
+	}
+}
+
+// sendOnce issues a single request through the request handler. The request
+// is bound to ctx with the per-request timeout added.
+func (bl Bricklink) sendOnce(ctx context.Context, method, uri string, payload []byte) (body []byte, err error) {
+	if bl.limiter != nil {
+		err = bl.limiter.wait(ctx, priorityOf(ctx))
+		if err != nil {
+			return body, err
+		}
+	}
+
+	if bl.logger != nil && bl.slowThreshold > 0 {
+		start := time.Now()
+		defer func() {
+			if d := time.Since(start); d > bl.slowThreshold {
+				bl.logger.Printf("bricklinkapi: slow request %v %v took %v", method, uri, d)
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bl.timeout(method))
+	defer cancel()
+
+	if h, ok := bl.request.(contextRequestHandler); ok {
+		return h.requestContext(ctx, method, uri, payload)
+	}
+	return bl.request.Request(method, uri)
+}
+
+// timeout returns the timeout of a single request with the given method
+func (bl Bricklink) timeout(method string) time.Duration {
+	if method == "GET" && bl.readTimeout > 0 {
+		return bl.readTimeout
+	}
+	if method != "GET" && bl.writeTimeout > 0 {
+		return bl.writeTimeout
+	}
+	return requestTimeout
+}
+
+// getParsed issues a GET request and decodes the response data into v.
+// Successful responses are served from and stored in the cache, if enabled.
+// If v points to a slice it is never left nil, even if decoding fails.
+func (bl Bricklink) getParsed(ctx context.Context, uri string, v interface{}) error {
+	_, err := bl.getParsedAt(ctx, uri, v, 0)
+	return err
+}
+
+// getParsedAt is like getParsed but also returns when the response was
+// fetched. Cached responses older than maxAge are fetched again, unless
+// maxAge is 0.
+func (bl Bricklink) getParsedAt(ctx context.Context, uri string, v interface{}, maxAge time.Duration) (time.Time, error) {
+	defer emptySlice(v)
+
+	if bl.cache != nil {
+		body, stored, ok := bl.cache.getStored(uri)
+		if ok && (maxAge == 0 || time.Since(stored) <= maxAge) {
+			return stored, bl.decode(body, v)
+		}
+	}
+
+	fetch := func() ([]byte, error) {
+		body, err := bl.send(ctx, "GET", uri, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// give truncated responses one extra attempt if retries are enabled
+		if bl.retries > 0 && truncated(body) && retryAllowed(ctx) {
+			return bl.send(ctx, "GET", uri, nil)
+		}
+		return body, nil
+	}
+
+	var body []byte
+	var err error
+	if bl.flight != nil {
+		body, err = bl.flight.do(ctx, uri, fetch)
+	} else {
+		body, err = fetch()
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	fetched := time.Now()
+
+	err = bl.decode(body, v)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if bl.cache != nil {
+		bl.cache.setStored(uri, body, fetched)
+	}
+
+	return fetched, nil
+}
+
+// helper function to check if a response body is cut off, i.e. it is
+// neither valid JSON nor an HTML page
+func truncated(body []byte) bool {
+	return !json.Valid(body) && !isHTML(body)
+}
+
+// helper function to append params as query string to an uri. Params are
+// sorted by key, so the same params always result in the same uri, and
+// percent encoded as for the signature.
+func buildURI(uri string, params map[string]string) string {
+	if len(params) == 0 {
+		return uri
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var paramString string
+	for _, k := range keys {
+		if paramString != "" {
+			paramString += "&"
+		}
+		paramString += encode(k) + "=" + encode(params[k])
+	}
+
+	return uri + "?" + paramString
 }
 
 // helper function to validate a param
@@ -208,6 +500,15 @@ func validateParam(param string, list []string) (err error) {
 	return nil
 }
 
+// validParam is validateParam unless validation is skipped, see
+// WithSkipValidation
+func (bl Bricklink) validParam(param string, list []string) error {
+	if bl.skipValidation {
+		return nil
+	}
+	return validateParam(param, list)
+}
+
 // helper function to check if a string is in a slice
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
@@ -1,9 +1,94 @@
 package bricklinkapi
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
+// fakeRequest is a request handler returning a fixed body and recording
+// the last request it received
+type fakeRequest struct {
+	mu      sync.Mutex
+	calls   int
+	body    []byte
+	err     error
+	ctxErr  error
+	method  string
+	uri     string
+	payload []byte
+
+	// release, if set, holds every request until it is closed
+	release chan struct{}
+}
+
+func (f *fakeRequest) Request(method, uri string) ([]byte, error) {
+	return f.requestContext(context.Background(), method, uri, nil)
+}
+
+func (f *fakeRequest) requestContext(ctx context.Context, method, uri string, payload []byte) ([]byte, error) {
+	_, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		return nil, errors.New("request without deadline")
+	}
+	f.mu.Lock()
+	f.calls++
+	f.ctxErr, f.method, f.uri, f.payload = ctx.Err(), method, uri, payload
+	f.mu.Unlock()
+
+	if f.release != nil {
+		<-f.release
+	}
+	return f.body, f.err
+}
+
+// routeRequest is a request handler answering with the body of the longest
+// route whose key is a prefix of the uri
+type routeRequest map[string]string
+
+func (r routeRequest) Request(method, uri string) ([]byte, error) {
+	return r.requestContext(context.Background(), method, uri, nil)
+}
+
+func (r routeRequest) requestContext(ctx context.Context, method, uri string, payload []byte) ([]byte, error) {
+	match := ""
+	for prefix := range r {
+		if strings.HasPrefix(uri, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	if match == "" {
+		return nil, errors.New("no route for " + uri)
+	}
+	return []byte(r[match]), nil
+}
+
+// recordingRequest is a routeRequest recording the uris it received and the
+// payloads of writes
+type recordingRequest struct {
+	routes   routeRequest
+	mu       sync.Mutex
+	uris     []string
+	payloads []string
+}
+
+func (r *recordingRequest) Request(method, uri string) ([]byte, error) {
+	return r.requestContext(context.Background(), method, uri, nil)
+}
+
+func (r *recordingRequest) requestContext(ctx context.Context, method, uri string, payload []byte) ([]byte, error) {
+	r.mu.Lock()
+	r.uris = append(r.uris, uri)
+	if payload != nil {
+		r.payloads = append(r.payloads, string(payload))
+	}
+	r.mu.Unlock()
+	return r.routes.requestContext(ctx, method, uri, payload)
+}
+
 func TestStringInSlice(t *testing.T) {
 	testCases := []struct {
 		desc string
@@ -34,3 +119,116 @@ func TestStringInSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestBaseContext(t *testing.T) {
+	base, cancel := context.WithCancel(context.Background())
+	f := &fakeRequest{body: []byte("{}")}
+	bl := New("", "", "", "", WithBaseContext(base))
+	bl.request = f
+
+	// methods without context parameter are derived from the base context
+	cancel()
+	if _, err := bl.GetColorList(); err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if f.ctxErr == nil {
+		t.Errorf("\nbase context cancellation was not propagated\n")
+	}
+
+	// a per-call context takes precedence
+	if _, err := bl.send(context.Background(), "GET", "/colors", nil); err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if f.ctxErr != nil {
+		t.Errorf("\nper-call context should not be bound to the base context\n")
+	}
+}
+
+func TestGetItemImageColor(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		itemType string
+		colorID  int
+		expURI   string
+	}{
+		{desc: "testing part", itemType: "PART", colorID: 5, expURI: "/items/PART/3001/images/5"},
+		{desc: "testing set", itemType: "SET", colorID: 5, expURI: "/items/SET/3001/images/0"},
+	}
+	for _, tc := range testCases {
+		f := &fakeRequest{body: []byte("{}")}
+		bl := New("", "", "", "")
+		bl.request = f
+
+		bl.GetItemImage(tc.itemType, "3001", tc.colorID)
+		if f.uri != tc.expURI {
+			t.Errorf("\n%v, want: %v, got: %v\n", tc.desc, tc.expURI, f.uri)
+		}
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	bl := New("", "", "", "", WithReadTimeout(5*time.Second), WithWriteTimeout(time.Minute))
+
+	testCases := []struct {
+		desc   string
+		bl     *Bricklink
+		method string
+		exp    time.Duration
+	}{
+		{desc: "testing read timeout", bl: bl, method: "GET", exp: 5 * time.Second},
+		{desc: "testing write timeout of PUT", bl: bl, method: "PUT", exp: time.Minute},
+		{desc: "testing write timeout of POST", bl: bl, method: "POST", exp: time.Minute},
+		{desc: "testing default", bl: New("", "", "", ""), method: "GET", exp: requestTimeout},
+		{desc: "testing default write timeout", bl: New("", "", "", "", WithReadTimeout(time.Second)), method: "DELETE", exp: requestTimeout},
+	}
+	for _, tc := range testCases {
+		if result := tc.bl.timeout(tc.method); result != tc.exp {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.exp, result)
+		}
+	}
+}
+
+func TestSkipValidation(t *testing.T) {
+	for _, skip := range []bool{false, true} {
+		f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"inventory_id":1}}`)}
+		bl := New("", "", "", "", WithSkipValidation(skip))
+		bl.request = f
+
+		_, err := bl.GetItemParsed("BRICK", "3001")
+		_, errUpdate := bl.UpdateInventory(context.Background(), InventoryUpdate{InventoryID: 1, Bulk: -1})
+		_, errGuide := bl.GetPriceGuide("PART", "3001", PriceGuideOptions{CurrencyCode: "XYZ"})
+		_, errCombinations := bl.PriceGuideCombinations("BRICK")
+		if (err == nil) != skip || (errUpdate == nil) != skip || (errGuide == nil) != skip || (errCombinations == nil) != skip {
+			t.Errorf("\nskip %v: unexpected errors: %v, %v, %v, %v\n", skip, err, errUpdate, errGuide, errCombinations)
+		}
+		if skip && f.calls != 3 || !skip && f.calls != 0 {
+			t.Errorf("\nskip %v: unexpected requests: %v\n", skip, f.calls)
+		}
+
+		_, err = bl.GetItemParsed("PART", "")
+		if err == nil {
+			t.Errorf("\nskip %v: empty item numbers must be rejected\n", skip)
+		}
+	}
+}
+
+func TestGetItemBytes(t *testing.T) {
+	body := `{"meta":{"code":200},"data":{"no":"3001"}}`
+	f := &fakeRequest{body: []byte(body)}
+	bl := New("", "", "", "")
+	bl.request = f
+
+	b, err := bl.GetItemBytes("PART", "3001")
+	if err != nil || string(b) != body || f.uri != "/items/PART/3001" {
+		t.Errorf("\nunexpected result: %s (%v) for %v\n", b, err, f.uri)
+	}
+	s, err := bl.GetItem("PART", "3001")
+	if err != nil || s != body {
+		t.Errorf("\nunexpected result: %v (%v)\n", s, err)
+	}
+
+	_, err = bl.GetItemBytes("BRICK", "3001")
+	if err == nil {
+		t.Errorf("\nexpected error for invalid item type\n")
+	}
+}
@@ -0,0 +1,104 @@
+package bricklinkapi
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cache is a concurrency safe store for response bodies of GET requests,
+// keyed by uri. Entries expire after ttl, unless ttl is 0. If maxEntries is
+// set, the least recently used entries are evicted beyond it; a negative
+// maxEntries marks the cache as disabled, see enabled.
+type cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+
+	// lru holds the entries, most recently used first
+	lru *list.List
+}
+
+type cacheEntry struct {
+	key     string
+	body    []byte
+	stored  time.Time
+	expires time.Time
+}
+
+func newCache(ttl time.Duration, maxEntries int) *cache {
+	return &cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// enabled reports whether the cache is bounded, i.e. its entries expire or
+// are evicted, and caching wasn't disabled with a size limit of 0 or below
+func (c *cache) enabled() bool {
+	return c.maxEntries >= 0 && (c.ttl > 0 || c.maxEntries > 0)
+}
+
+// get returns the cached body for key, if present and not expired
+func (c *cache) get(key string) (body []byte, ok bool) {
+	body, _, ok = c.getStored(key)
+	return body, ok
+}
+
+// getStored is like get but also returns when the body was stored
+func (c *cache) getStored(key string) (body []byte, stored time.Time, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, stored, false
+	}
+	e := el.Value.(*cacheEntry)
+	if c.ttl > 0 && time.Now().After(e.expires) {
+		c.remove(el)
+		return nil, stored, false
+	}
+
+	c.lru.MoveToFront(el)
+	return e.body, e.stored, true
+}
+
+// set stores body for key, evicting the least recently used entry if the
+// cache is full
+func (c *cache) set(key string, body []byte) {
+	c.setStored(key, body, time.Now())
+}
+
+// setStored is like set but records body as stored at the given time
+func (c *cache) setStored(key string, body []byte, stored time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &cacheEntry{
+		key:     key,
+		body:    body,
+		stored:  stored,
+		expires: stored.Add(c.ttl),
+	}
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(e)
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove deletes an entry, c.mu must be held
+func (c *cache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
@@ -0,0 +1,96 @@
+package bricklinkapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWarmup(t *testing.T) {
+	f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[{"color_id":11,"color_name":"Black","category_id":11,"category_name":"Brick"}]}`)}
+	bl := New("", "", "", "", WithCache(time.Minute))
+	bl.request = f
+
+	err := bl.Warmup(context.Background())
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if f.calls != 2 {
+		t.Errorf("\nwarmup, want: %v requests, got: %v\n", 2, f.calls)
+	}
+
+	name, err := bl.ColorName(11)
+	if err != nil || name != "Black" {
+		t.Errorf("\ncolor name, want: %v, got: %v (%v)\n", "Black", name, err)
+	}
+	name, err = bl.CategoryName(11)
+	if err != nil || name != "Brick" {
+		t.Errorf("\ncategory name, want: %v, got: %v (%v)\n", "Brick", name, err)
+	}
+	if f.calls != 2 {
+		t.Errorf("\nname lookups should be served from the cache, got: %v requests\n", f.calls)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c := newCache(time.Millisecond, 0)
+	c.set("foo", []byte("bar"))
+	if _, ok := c.get("foo"); !ok {
+		t.Errorf("\nentry should be cached\n")
+	}
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := c.get("foo"); ok {
+		t.Errorf("\nentry should be expired\n")
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := newCache(0, 2)
+	c.set("a", []byte("1"))
+	c.set("b", []byte("2"))
+	c.get("a")
+	c.set("c", []byte("3"))
+
+	testCases := []struct {
+		desc   string
+		key    string
+		cached bool
+	}{
+		{desc: "testing recently used", key: "a", cached: true},
+		{desc: "testing evicted", key: "b", cached: false},
+		{desc: "testing last set", key: "c", cached: true},
+	}
+	for _, tc := range testCases {
+		if _, ok := c.get(tc.key); ok != tc.cached {
+			t.Errorf("%v, want cached: %v, got: %v\n", tc.desc, tc.cached, ok)
+		}
+	}
+}
+
+func TestCacheOptions(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		opts       []Option
+		enabled    bool
+		maxEntries int
+		ttl        time.Duration
+	}{
+		{desc: "testing combined options", opts: []Option{WithLRUCache(10), WithCache(time.Minute)}, enabled: true, maxEntries: 10, ttl: time.Minute},
+		{desc: "testing ttl only", opts: []Option{WithCache(time.Minute)}, enabled: true, ttl: time.Minute},
+		{desc: "testing size limit only", opts: []Option{WithCache(0), WithLRUCache(5)}, enabled: true, maxEntries: 5},
+		{desc: "testing ttl of 0", opts: []Option{WithCache(0)}, enabled: false},
+		{desc: "testing negative ttl", opts: []Option{WithCache(-time.Minute)}, enabled: false},
+		{desc: "testing size limit of 0", opts: []Option{WithLRUCache(0)}, enabled: false},
+		{desc: "testing negative size limit with ttl", opts: []Option{WithCache(time.Minute), WithLRUCache(-1)}, enabled: false},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "", tc.opts...)
+		if (bl.cache != nil) != tc.enabled {
+			t.Errorf("%v, want enabled: %v, got: %v\n", tc.desc, tc.enabled, bl.cache != nil)
+			continue
+		}
+		if tc.enabled && (bl.cache.maxEntries != tc.maxEntries || bl.cache.ttl != tc.ttl) {
+			t.Errorf("%v, want: %v entries, ttl %v, got: %v entries, ttl %v\n", tc.desc, tc.maxEntries, tc.ttl, bl.cache.maxEntries, bl.cache.ttl)
+		}
+	}
+}
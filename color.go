@@ -0,0 +1,191 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// Color is a BrickLink catalog color.
+type Color struct {
+	ColorID   int    `json:"color_id"`
+	ColorName string `json:"color_name"`
+	ColorCode string `json:"color_code"`
+	ColorType string `json:"color_type"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// Category is a BrickLink catalog category.
+type Category struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	ParentID     int    `json:"parent_id"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// GetColorListParsed querys for a list of all colors and returns them parsed.
+func (bl Bricklink) GetColorListParsed() (colors []Color, err error) {
+	return bl.colorList(bl.context())
+}
+
+// GetCategoryListParsed querys for a list of all categories and returns them parsed.
+func (bl Bricklink) GetCategoryListParsed() (categories []Category, err error) {
+	return bl.categoryList(bl.context())
+}
+
+// ColorName resolves a color ID to its name. The color list is served from
+// the cache if caching is enabled.
+func (bl Bricklink) ColorName(colorID int) (string, error) {
+	colors, err := bl.GetColorListParsed()
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range colors {
+		if c.ColorID == colorID {
+			return c.ColorName, nil
+		}
+	}
+
+	return "", fmt.Errorf("color \"%v\" not found", colorID)
+}
+
+// CategoryName resolves a category ID to its name. The category list is
+// served from the cache if caching is enabled.
+func (bl Bricklink) CategoryName(categoryID int) (string, error) {
+	categories, err := bl.GetCategoryListParsed()
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range categories {
+		if c.CategoryID == categoryID {
+			return c.CategoryName, nil
+		}
+	}
+
+	return "", fmt.Errorf("category \"%v\" not found", categoryID)
+}
+
+// Warmup fetches the color and category lists concurrently, so they are
+// cached before the first name lookup. It is safe to call while other
+// requests are in flight. Without caching enabled the lists are fetched but
+// not kept.
+func (bl Bricklink) Warmup(ctx context.Context) error {
+	_, _, err := bl.referenceLists(ctx)
+	return err
+}
+
+// referenceLists fetches the color and category lists concurrently
+func (bl Bricklink) referenceLists(ctx context.Context) (colors []Color, categories []Category, err error) {
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		colors, errs[0] = bl.colorList(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		categories, errs[1] = bl.categoryList(ctx)
+	}()
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return colors, categories, err
+		}
+	}
+
+	return colors, categories, nil
+}
+
+// WarmCaches prefetches the color and category lists when caching is
+// enabled, so the first lookups are fast and bad credentials surface at
+// startup. It does nothing if caching is disabled, use Warmup to fetch the
+// lists regardless.
+func (bl Bricklink) WarmCaches(ctx context.Context) error {
+	if bl.cache == nil {
+		return nil
+	}
+	return bl.Warmup(ctx)
+}
+
+func (bl Bricklink) colorList(ctx context.Context) (colors []Color, err error) {
+	err = bl.getParsed(ctx, "/colors", &colors)
+	return colors, err
+}
+
+func (bl Bricklink) categoryList(ctx context.Context) (categories []Category, err error) {
+	err = bl.getParsed(ctx, "/categories", &categories)
+	return categories, err
+}
+
+// GetColorsByIDs resolves the IDs to colors. If the color list is cached it
+// is used without any request, otherwise the distinct colors are fetched
+// concurrently. The colors are returned in the order of ids; colors which
+// couldn't be resolved are left out and their errors returned as MultiError,
+// matching ErrNotFound for unknown IDs.
+func (bl Bricklink) GetColorsByIDs(ids ...int) ([]Color, error) {
+	ctx := bl.context()
+
+	byID := make(map[int]Color)
+	if bl.cache != nil {
+		if body, ok := bl.cache.get("/colors"); ok {
+			var colors []Color
+			if bl.decode(body, &colors) == nil {
+				for _, c := range colors {
+					byID[c.ColorID] = c
+				}
+			}
+		}
+	}
+
+	var missing []int
+	if len(byID) == 0 {
+		seen := make(map[int]bool)
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				missing = append(missing, id)
+			}
+		}
+	}
+
+	fetched := make([]Color, len(missing))
+	errs, _ := runBatch(ctx, len(missing), bl.batchOptions(nil), func(ctx context.Context, i int) error {
+		return bl.getParsed(ctx, "/colors/"+strconv.Itoa(missing[i]), &fetched[i])
+	})
+
+	failed := make(map[int]error)
+	for i, id := range missing {
+		if errs[i] != nil {
+			failed[id] = errs[i]
+			continue
+		}
+		byID[id] = fetched[i]
+	}
+
+	var colors []Color
+	var merr MultiError
+	for _, id := range ids {
+		c, ok := byID[id]
+		switch {
+		case ok:
+			colors = append(colors, c)
+		case failed[id] != nil:
+			merr = append(merr, fmt.Errorf("color %d: %w", id, failed[id]))
+		default:
+			merr = append(merr, fmt.Errorf("color %d: %w", id, ErrNotFound))
+		}
+	}
+
+	return colors, merr.errOrNil()
+}
@@ -0,0 +1,37 @@
+package bricklinkapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetColorsByIDs(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/colors/1": `{"meta":{"code":200},"data":{"color_id":1,"color_name":"White"}}`,
+		"/colors/5": `{"meta":{"code":200},"data":{"color_id":5,"color_name":"Red"}}`,
+	}
+
+	colors, err := bl.GetColorsByIDs(5, 9, 1, 5)
+	merr, ok := err.(MultiError)
+	if !ok || len(merr) != 1 {
+		t.Errorf("\nwant: one error for color 9, got: %v\n", err)
+	}
+	if len(colors) != 3 || colors[0].ColorName != "Red" || colors[1].ColorName != "White" || colors[2].ColorName != "Red" {
+		t.Errorf("\nunexpected colors: %+v\n", colors)
+	}
+
+	// with the color list cached no request is made
+	bl = New("", "", "", "", WithCache(time.Minute))
+	bl.request = &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[{"color_id":1,"color_name":"White"},{"color_id":5,"color_name":"Red"}]}`)}
+	_, err = bl.GetColorListParsed()
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	bl.request = routeRequest{}
+
+	colors, err = bl.GetColorsByIDs(1, 5, 9)
+	if err == nil || len(colors) != 2 || colors[1].ColorName != "Red" {
+		t.Errorf("\nunexpected result: %+v, %v\n", colors, err)
+	}
+}
@@ -0,0 +1,29 @@
+package bricklinkapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// currencyCodes are the ISO 4217 codes of the currencies BrickLink supports
+var currencyCodes = []string{
+	"ARS", "AUD", "BGN", "BRL", "CAD", "CHF", "CNY", "CZK", "DKK", "EUR",
+	"GBP", "HKD", "HRK", "HUF", "IDR", "ILS", "INR", "ISK", "JPY", "KRW",
+	"MXN", "MYR", "NOK", "NZD", "PHP", "PLN", "RON", "RSD", "RUB", "SEK",
+	"SGD", "THB", "TRY", "TWD", "UAH", "USD", "ZAR",
+}
+
+// NormalizeCurrency validates a currency code and returns it uppercased,
+// e.g. "eur" becomes "EUR". Codes which are not three letters or not
+// supported by BrickLink are rejected, as BrickLink would silently fall back
+// to the default currency for them.
+func NormalizeCurrency(code string) (string, error) {
+	c := strings.ToUpper(strings.TrimSpace(code))
+	if len(c) != 3 {
+		return "", fmt.Errorf("currency code \"%v\" is not a three letter ISO 4217 code", code)
+	}
+	if !stringInSlice(c, currencyCodes) {
+		return "", fmt.Errorf("currency code \"%v\" is not supported by BrickLink", code)
+	}
+	return c, nil
+}
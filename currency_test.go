@@ -0,0 +1,27 @@
+package bricklinkapi
+
+import (
+	"testing"
+)
+
+func TestNormalizeCurrency(t *testing.T) {
+	testCases := []struct {
+		desc string
+		code string
+		expS string
+		err  bool
+	}{
+		{desc: "testing uppercase", code: "EUR", expS: "EUR"},
+		{desc: "testing lowercase", code: "usd", expS: "USD"},
+		{desc: "testing whitespace", code: " gbp ", expS: "GBP"},
+		{desc: "testing too long", code: "EURO", err: true},
+		{desc: "testing empty", code: "", err: true},
+		{desc: "testing unknown", code: "XYZ", err: true},
+	}
+	for _, tc := range testCases {
+		result, err := NormalizeCurrency(tc.code)
+		if (err != nil) != tc.err || result != tc.expS {
+			t.Errorf("%v \"%v\", want: %v (error: %v), got: %v (%v)\n", tc.desc, tc.code, tc.expS, tc.err, result, err)
+		}
+	}
+}
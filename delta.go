@@ -0,0 +1,106 @@
+package bricklinkapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// InventoryUpdate holds the changes to apply to an inventory lot. Fields
+// left at their zero value are not changed.
+type InventoryUpdate struct {
+	InventoryID int `json:"-"`
+
+	// Quantity is the difference to the current quantity, e.g. -2. It is
+	// sent in BrickLink's signed delta form, e.g. "-2" or "+3".
+	Quantity  int    `json:"quantity,omitempty"`
+	UnitPrice *Money `json:"unit_price,omitempty"`
+
+	// Description and Remarks replace the free text fields of the lot. Their
+	// text is validated and normalized like that of new lots.
+	Description string `json:"description,omitempty"`
+	Remarks     string `json:"remarks,omitempty"`
+
+	// Completeness changes the completeness of a set lot
+	Completeness string `json:"completeness,omitempty"`
+
+	// Bulk sets the multiple buyers have to purchase the lot in, 1 removes
+	// the bulk requirement
+	Bulk int `json:"bulk,omitempty"`
+
+	// IsRetain sets whether the lot is kept in the inventory once it is sold
+	// out, instead of being deleted
+	IsRetain *bool `json:"is_retain,omitempty"`
+}
+
+// MarshalJSON encodes the update, writing Quantity in the signed delta form.
+func (u InventoryUpdate) MarshalJSON() ([]byte, error) {
+	type alias InventoryUpdate
+	aux := struct {
+		alias
+		Quantity string `json:"quantity,omitempty"`
+	}{alias: alias(u)}
+	if u.Quantity != 0 {
+		aux.Quantity = fmt.Sprintf("%+d", u.Quantity)
+	}
+
+	return json.Marshal(aux)
+}
+
+// DeltaOption configures PriceQuantityDelta.
+type DeltaOption func(*deltaOptions)
+
+type deltaOptions struct {
+	priceThreshold int64
+}
+
+// DeltaPriceThreshold sets the minimum price difference for a price change to
+// be emitted. Smaller differences are ignored to avoid churny micro updates.
+func DeltaPriceThreshold(threshold Money) DeltaOption {
+	return func(o *deltaOptions) {
+		o.priceThreshold = threshold.Amount
+	}
+}
+
+// PriceQuantityDelta compares the current lots with the desired ones, matched
+// by inventory ID, and returns the price and quantity updates needed to get
+// from current to desired. Desired lots without a current counterpart are
+// ignored, lots are never created or deleted.
+func PriceQuantityDelta(current, desired []Inventory, opts ...DeltaOption) []InventoryUpdate {
+	var o deltaOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	byID := make(map[int]Inventory, len(current))
+	for _, inv := range current {
+		byID[inv.InventoryID] = inv
+	}
+
+	var updates []InventoryUpdate
+	for _, want := range desired {
+		have, ok := byID[want.InventoryID]
+		if !ok {
+			continue
+		}
+
+		u := InventoryUpdate{
+			InventoryID: want.InventoryID,
+			Quantity:    want.Quantity - have.Quantity,
+		}
+
+		diff := want.UnitPrice.Amount - have.UnitPrice.Amount
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff != 0 && diff >= o.priceThreshold {
+			price := want.UnitPrice
+			u.UnitPrice = &price
+		}
+
+		if u.Quantity != 0 || u.UnitPrice != nil {
+			updates = append(updates, u)
+		}
+	}
+
+	return updates
+}
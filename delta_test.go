@@ -0,0 +1,52 @@
+package bricklinkapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPriceQuantityDelta(t *testing.T) {
+	current := []Inventory{
+		{InventoryID: 1, Quantity: 10, UnitPrice: Money{Amount: 1000}},
+		{InventoryID: 2, Quantity: 5, UnitPrice: Money{Amount: 1000}},
+		{InventoryID: 3, Quantity: 5, UnitPrice: Money{Amount: 1000}},
+	}
+	desired := []Inventory{
+		{InventoryID: 1, Quantity: 8, UnitPrice: Money{Amount: 1000}}, // quantity only
+		{InventoryID: 2, Quantity: 5, UnitPrice: Money{Amount: 1010}}, // below threshold
+		{InventoryID: 3, Quantity: 5, UnitPrice: Money{Amount: 2000}}, // price only
+		{InventoryID: 4, Quantity: 1, UnitPrice: Money{Amount: 1000}}, // unknown lot
+	}
+
+	updates := PriceQuantityDelta(current, desired, DeltaPriceThreshold(Money{Amount: 100}))
+	if len(updates) != 2 {
+		t.Fatalf("\nwant: %v updates, got: %+v\n", 2, updates)
+	}
+	if updates[0].InventoryID != 1 || updates[0].Quantity != -2 || updates[0].UnitPrice != nil {
+		t.Errorf("\nquantity update, got: %+v\n", updates[0])
+	}
+	if updates[1].InventoryID != 3 || updates[1].Quantity != 0 || updates[1].UnitPrice.Amount != 2000 {
+		t.Errorf("\nprice update, got: %+v\n", updates[1])
+	}
+}
+
+func TestInventoryUpdateJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		quantity int
+		expS     string
+	}{
+		{desc: "testing decrease", quantity: -2, expS: `{"quantity":"-2"}`},
+		{desc: "testing increase", quantity: 3, expS: `{"quantity":"+3"}`},
+		{desc: "testing unchanged", quantity: 0, expS: `{}`},
+	}
+	for _, tc := range testCases {
+		b, err := json.Marshal(InventoryUpdate{InventoryID: 1, Quantity: tc.quantity})
+		if err != nil {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if string(b) != tc.expS {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expS, string(b))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package bricklinkapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors for the meta codes documented by BrickLink. A *BrickLinkError
+// matches the error of its code with errors.Is, e.g.
+//
+//	if errors.Is(err, bricklinkapi.ErrNotFound) {
+var (
+	// ErrInvalidParameter is returned for an invalid uri, request body or parameter
+	ErrInvalidParameter = &BrickLinkError{Code: 400, Message: "PARAMETER_MISSING_OR_INVALID"}
+	// ErrUnauthorized is returned for invalid OAuth credentials or signatures
+	ErrUnauthorized = &BrickLinkError{Code: 401, Message: "BAD_OAUTH_REQUEST"}
+	// ErrForbidden is returned if access is denied, e.g. for a request from
+	// an IP address not registered for the token
+	ErrForbidden = &BrickLinkError{Code: 403, Message: "PERMISSION_DENIED"}
+	// ErrNotFound is returned if the requested resource doesn't exist
+	ErrNotFound = &BrickLinkError{Code: 404, Message: "RESOURCE_NOT_FOUND"}
+	// ErrUnsupportedMediaType is returned for a request body which is not JSON
+	ErrUnsupportedMediaType = &BrickLinkError{Code: 415, Message: "UNSUPPORTED_MEDIA_TYPE"}
+	// ErrResourceError is returned if the resource can't be changed as requested
+	ErrResourceError = &BrickLinkError{Code: 422, Message: "RESOURCE_UPDATE_NOT_ALLOWED"}
+	// ErrServerError is returned for an internal error of BrickLink
+	ErrServerError = &BrickLinkError{Code: 500, Message: "INTERNAL_SERVER_ERROR"}
+	// ErrServiceUnavailable is returned during maintenance, when BrickLink
+	// responds with an HTML page instead of JSON. The description holds the
+	// start of the page.
+	ErrServiceUnavailable = &BrickLinkError{Code: 503, Message: "SERVICE_UNAVAILABLE"}
+)
+
+// ErrMissingMeta is returned with WithStrictMeta for responses without meta
+// block or meta code, e.g. altered by a proxy. The error message holds the
+// start of the body.
+var ErrMissingMeta = errors.New("response has no meta block")
+
+// missingMeta returns the error for a response body without meta block
+func missingMeta(body []byte) error {
+	return fmt.Errorf("%w: %v", ErrMissingMeta, snippet(body))
+}
+
+// maxSnippet is the maximum length of the response snippet in the
+// description of a service unavailable error
+const maxSnippet = 200
+
+// serviceUnavailable returns the error for a non JSON response body
+func serviceUnavailable(body []byte) *BrickLinkError {
+	return &BrickLinkError{
+		Code:        ErrServiceUnavailable.Code,
+		Message:     ErrServiceUnavailable.Message,
+		Description: "non JSON response: " + snippet(body),
+		body:        capBody(body),
+	}
+}
+
+// helper function to shorten a response body for an error message
+func snippet(body []byte) string {
+	s := strings.Join(strings.Fields(string(body)), " ")
+	if len(s) > maxSnippet {
+		s = s[:maxSnippet] + "..."
+	}
+	return s
+}
+
+// helper function to check if a response body is an HTML page
+func isHTML(body []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(body), []byte("<"))
+}
+
+// Is reports whether target is a *BrickLinkError with the same code, so
+// errors.Is matches the errors above.
+func (e *BrickLinkError) Is(target error) bool {
+	t, ok := target.(*BrickLinkError)
+	return ok && t.Code == e.Code
+}
+
+// CodeOf returns the BrickLink meta code of err, or 0 if err is not caused by
+// a BrickLink error response.
+func CodeOf(err error) int {
+	var e *BrickLinkError
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return 0
+}
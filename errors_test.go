@@ -0,0 +1,69 @@
+package bricklinkapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCodes(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		err     error
+		target  error
+		expCode int
+	}{
+		{desc: "testing not found", err: &BrickLinkError{Code: 404, Message: "RESOURCE_NOT_FOUND"}, target: ErrNotFound, expCode: 404},
+		{desc: "testing wrapped", err: fmt.Errorf("order 1: %w", &BrickLinkError{Code: 401}), target: ErrUnauthorized, expCode: 401},
+		{desc: "testing code only", err: &BrickLinkError{Code: 422}, target: ErrResourceError, expCode: 422},
+		{desc: "testing other error", err: errors.New("connection reset"), target: nil, expCode: 0},
+	}
+	for _, tc := range testCases {
+		if tc.target != nil && !errors.Is(tc.err, tc.target) {
+			t.Errorf("%v, %v should match %v\n", tc.desc, tc.err, tc.target)
+		}
+		if errors.Is(tc.err, ErrServerError) {
+			t.Errorf("%v, %v should not match %v\n", tc.desc, tc.err, ErrServerError)
+		}
+		if result := CodeOf(tc.err); result != tc.expCode {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expCode, result)
+		}
+	}
+}
+
+func TestDecodeHTML(t *testing.T) {
+	err := decode([]byte("\n<!DOCTYPE html>\n<html>  <body>Maintenance</body></html>"), &[]Color{})
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("\nwant: %v, got: %v\n", ErrServiceUnavailable, err)
+	}
+	if e, ok := err.(*BrickLinkError); !ok || e.Description != "non JSON response: <!DOCTYPE html> <html> <body>Maintenance</body></html>" {
+		t.Errorf("\nunexpected description: %v\n", err)
+	}
+}
+
+func TestDecodeMissingMeta(t *testing.T) {
+	testCases := []struct {
+		desc string
+		body string
+	}{
+		{desc: "testing no meta", body: `{"data":[{"color_id":1}]}`},
+		{desc: "testing null meta", body: `{"meta":null,"data":[]}`},
+		{desc: "testing empty meta", body: `{"meta":{},"data":[]}`},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "", WithStrictMeta())
+		err := bl.decode([]byte(tc.body), &[]Color{})
+		if !errors.Is(err, ErrMissingMeta) {
+			t.Errorf("%v, strict, want: %v, got: %v\n", tc.desc, ErrMissingMeta, err)
+		}
+		if CodeOf(err) != 0 {
+			t.Errorf("%v, strict, missing meta should have no code, got: %v\n", tc.desc, CodeOf(err))
+		}
+
+		bl = New("", "", "", "")
+		err = bl.decode([]byte(tc.body), &[]Color{})
+		if err != nil {
+			t.Errorf("%v, lenient, unexpected error: %v\n", tc.desc, err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package bricklinkapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// orderColumns maps the CSV column names of OrdersToCSV to their values
+var orderColumns = map[string]func(Order) string{
+	"order_id":      func(o Order) string { return strconv.Itoa(o.OrderID) },
+	"date_ordered":  func(o Order) string { return formatTime(o.DateOrdered) },
+	"buyer_name":    func(o Order) string { return o.BuyerName },
+	"buyer_email":   func(o Order) string { return o.BuyerEmail },
+	"status":        func(o Order) string { return o.Status },
+	"currency_code": func(o Order) string { return o.Cost.CurrencyCode },
+	"subtotal":      func(o Order) string { return o.Cost.Subtotal.String() },
+	"shipping":      func(o Order) string { return o.Cost.Shipping.String() },
+	"grand_total":   func(o Order) string { return o.Cost.GrandTotal.String() },
+	"total_count":   func(o Order) string { return strconv.Itoa(o.TotalCount) },
+	"unique_count":  func(o Order) string { return strconv.Itoa(o.UniqueCount) },
+}
+
+// DefaultOrderColumns are the columns OrdersToCSV writes if none are given.
+var DefaultOrderColumns = []string{"order_id", "date_ordered", "buyer_name", "status", "currency_code", "subtotal", "shipping", "grand_total"}
+
+// OrdersToCSV writes the orders as CSV to w, starting with a header row.
+// columns selects the columns and their order, DefaultOrderColumns are used
+// if none are given. Available are order_id, date_ordered, buyer_name,
+// buyer_email, status, currency_code, subtotal, shipping, grand_total,
+// total_count and unique_count.
+// Amounts are written as by Money.String, dates as RFC3339.
+func OrdersToCSV(orders []Order, w io.Writer, columns ...string) error {
+	if len(columns) == 0 {
+		columns = DefaultOrderColumns
+	}
+
+	values := make([]func(Order) string, len(columns))
+	for i, c := range columns {
+		v, ok := orderColumns[c]
+		if !ok {
+			return fmt.Errorf("column \"%v\" is not valid", c)
+		}
+		values[i] = v
+	}
+
+	cw := csv.NewWriter(w)
+	err := cw.Write(columns)
+	if err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for _, o := range orders {
+		for i, v := range values {
+			row[i] = v(o)
+		}
+		err = cw.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportPartOutCSV expands the set, prices each part as GetSetPartsValue does
+// and writes the parts as CSV to w: a header row, one row per part with item
+// type, item number, color, quantity, unit price and line total, and a totals
+// row.
+//
+// Parts whose price guide could not be fetched are left out of the lines and
+// listed in a trailing warnings section instead. Their errors are returned
+// as MultiError after the CSV has been written completely.
+func (bl Bricklink) ExportPartOutCSV(ctx context.Context, setNumber string, opts PriceGuideOptions, w io.Writer) error {
+	value, errs, err := bl.pricedParts(ctx, setNumber, opts)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"item_type", "item_no", "color_id", "quantity", "unit_price", "line_total"})
+
+	var quantity int
+	var merr MultiError
+	for i, p := range value.Parts {
+		if errs[i] != nil {
+			merr = append(merr, errs[i])
+			continue
+		}
+		quantity += p.Quantity
+		cw.Write([]string{
+			p.Item.Type,
+			p.Item.No,
+			strconv.Itoa(p.ColorID),
+			strconv.Itoa(p.Quantity),
+			p.PriceGuide.AvgPrice.String(),
+			p.Value.String(),
+		})
+	}
+	cw.Write([]string{"total", "", "", strconv.Itoa(quantity), "", value.Total.String()})
+
+	if len(merr) > 0 {
+		cw.Write(nil)
+		cw.Write([]string{"warnings"})
+		for _, e := range merr {
+			cw.Write([]string{e.Error()})
+		}
+	}
+
+	// errors of Write are kept by the writer and reported by Error
+	cw.Flush()
+	err = cw.Error()
+	if err != nil {
+		return err
+	}
+
+	return merr.errOrNil()
+}
+
+// Format is an export format.
+type Format int
+
+// Export formats supported by ExportInventory
+const (
+	FormatJSON Format = iota
+	FormatCSV
+)
+
+// flushEvery is the number of lots ExportInventory writes between flushes
+const flushEvery = 100
+
+// inventoryColumns are the CSV columns written by ExportInventory
+var inventoryColumns = []string{"inventory_id", "item_type", "item_no", "color_id", "quantity", "new_or_used", "completeness",
+	"unit_price", "my_cost", "description", "remarks", "bulk", "is_retain", "is_stock_room", "date_created"}
+
+// ExportInventory writes the complete store inventory to w as JSON array or
+// CSV with a header row, e.g. for backups. The pages of the inventory (see
+// GetInventoryListAll) are decoded and written lot by lot, so the parsed
+// inventory is never held, and the output is flushed every 100 lots. Only the
+// response body of the current page is held, as request handlers return
+// complete bodies.
+//
+// The export stops with the error if ctx is cancelled or a request fails
+// mid-stream. What was written so far is flushed, a JSON array is closed
+// first, so the output is valid but incomplete.
+func (bl Bricklink) ExportInventory(ctx context.Context, w io.Writer, format Format) error {
+	bw := bufio.NewWriter(w)
+	cw := csv.NewWriter(bw)
+
+	var write func(Inventory) error
+	var closing string
+	switch format {
+	case FormatJSON:
+		bw.WriteString("[")
+		closing = "]"
+		first := true
+		write = func(inv Inventory) error {
+			b, err := json.Marshal(inv)
+			if err != nil {
+				return err
+			}
+			if !first {
+				bw.WriteString(",")
+			}
+			first = false
+			_, err = bw.Write(b)
+			return err
+		}
+	case FormatCSV:
+		cw.Write(inventoryColumns)
+		write = func(inv Inventory) error {
+			return cw.Write([]string{
+				strconv.Itoa(inv.InventoryID),
+				inv.Item.Type,
+				inv.Item.No,
+				strconv.Itoa(inv.ColorID),
+				strconv.Itoa(inv.Quantity),
+				inv.NewOrUsed,
+				inv.Completeness,
+				inv.UnitPrice.String(),
+				inv.MyCost.String(),
+				inv.Description,
+				inv.Remarks,
+				strconv.Itoa(inv.Bulk),
+				strconv.FormatBool(inv.IsRetain),
+				strconv.FormatBool(inv.IsStockRoom),
+				formatTime(inv.DateCreated),
+			})
+		}
+	default:
+		return fmt.Errorf("export format %v is not valid", format)
+	}
+
+	flush := func() error {
+		cw.Flush()
+		err := cw.Error()
+		if err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	var written int
+	var inv Inventory
+	err := bl.eachPageBody(ctx, "/inventories", nil, func(body []byte) (Meta, int, error) {
+		return bl.decodeEach(body, &inv, func() error {
+			err := ctx.Err()
+			if err != nil {
+				return err
+			}
+			err = write(inv)
+			if err != nil {
+				return err
+			}
+
+			written++
+			if written%flushEvery == 0 {
+				return flush()
+			}
+			return nil
+		})
+	})
+
+	bw.WriteString(closing)
+	if err != nil {
+		flush()
+		return err
+	}
+	return flush()
+}
+
+// helper function to format a timestamp as RFC3339, empty for the zero time
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
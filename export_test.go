@@ -0,0 +1,137 @@
+package bricklinkapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOrdersToCSV(t *testing.T) {
+	orders := []Order{
+		{OrderID: 1, BuyerName: "foo", Status: "PAID", TotalCount: 12, UniqueCount: 3,
+			DateOrdered: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Cost:        OrderCost{CurrencyCode: "EUR", GrandTotal: Money{Amount: 12345}}},
+	}
+
+	var buf bytes.Buffer
+	err := OrdersToCSV(orders, &buf, "order_id", "date_ordered", "buyer_name", "grand_total", "total_count", "unique_count")
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+
+	exp := "order_id,date_ordered,buyer_name,grand_total,total_count,unique_count\n1,2020-01-02T03:04:05Z,foo,1.2345,12,3\n"
+	if buf.String() != exp {
+		t.Errorf("\nwant: %q, got: %q\n", exp, buf.String())
+	}
+
+	err = OrdersToCSV(orders, &buf, "foo")
+	if err == nil {
+		t.Errorf("\nunknown column, want error, got nil\n")
+	}
+}
+
+func TestExportPartOutCSV(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/items/SET/6020-1/subsets": `{"meta":{"code":200},"data":[
+			{"entries":[{"item":{"no":"3001","type":"PART"},"color_id":5,"quantity":4}]},
+			{"entries":[{"item":{"no":"3002","type":"PART"},"color_id":1,"quantity":2}]}]}`,
+		"/items/PART/3001/price": `{"meta":{"code":200},"data":{"currency_code":"USD","avg_price":"0.2500"}}`,
+		"/items/PART/3002/price": `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`,
+	}
+
+	var buf bytes.Buffer
+	err := bl.ExportPartOutCSV(context.Background(), "6020-1", PriceGuideOptions{}, &buf)
+	if err == nil {
+		t.Errorf("\nexpected error for the unpriced part\n")
+	}
+
+	want := "item_type,item_no,color_id,quantity,unit_price,line_total\n" +
+		"PART,3001,5,4,0.2500,1.0000\n" +
+		"total,,,4,,1.0000\n" +
+		"\n" +
+		"warnings\n"
+	if !strings.HasPrefix(buf.String(), want) || !strings.Contains(buf.String(), "part PART 3002 color 1") {
+		t.Errorf("\nwant prefix:\n%v\ngot:\n%v\n", want, buf.String())
+	}
+}
+
+func TestExportInventory(t *testing.T) {
+	pages := []scriptedResult{
+		{body: `{"meta":{"code":200,"next_cursor":"a"},"data":[{"inventory_id":1,"item":{"no":"3001","type":"PART"},"quantity":2,"unit_price":"0.1000"}]}`},
+		{body: `{"meta":{"code":200},"data":[{"inventory_id":2,"item":{"no":"3002","type":"PART"},"quantity":1,"description":"a, \"b\""}]}`},
+	}
+
+	testCases := []struct {
+		desc   string
+		format Format
+		expS   string
+	}{
+		{desc: "testing csv", format: FormatCSV, expS: "inventory_id,item_type,item_no,color_id,quantity,new_or_used,completeness,unit_price,my_cost,description,remarks,bulk,is_retain,is_stock_room,date_created\n" +
+			"1,PART,3001,0,2,,,0.1000,0.0000,,,0,false,false,\n" +
+			"2,PART,3002,0,1,,,0.0000,0.0000,\"a, \"\"b\"\"\",,0,false,false,\n"},
+		{desc: "testing json", format: FormatJSON},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "")
+		bl.request = &scriptedRequest{results: append([]scriptedResult(nil), pages...)}
+
+		var buf bytes.Buffer
+		err := bl.ExportInventory(context.Background(), &buf, tc.format)
+		if err != nil {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+
+		if tc.format == FormatJSON {
+			var lots []Inventory
+			err = json.Unmarshal(buf.Bytes(), &lots)
+			if err != nil || len(lots) != 2 || lots[1].Description != `a, "b"` {
+				t.Errorf("%v, invalid export: %v\n%v\n", tc.desc, err, buf.String())
+			}
+			continue
+		}
+		if buf.String() != tc.expS {
+			t.Errorf("%v, want:\n%v\ngot:\n%v\n", tc.desc, tc.expS, buf.String())
+		}
+	}
+}
+
+func TestExportInventoryCancelled(t *testing.T) {
+	firstPage := scriptedResult{body: `{"meta":{"code":200,"next_cursor":"a"},"data":[{"inventory_id":1}]}`}
+
+	testCases := []struct {
+		desc    string
+		results []scriptedResult
+		cancel  bool
+		lots    int
+	}{
+		{desc: "testing cancelled before the export", results: []scriptedResult{firstPage}, cancel: true, lots: 0},
+		{desc: "testing cancelled on the second page", results: []scriptedResult{firstPage, {err: context.Canceled}}, lots: 1},
+		{desc: "testing failure on the second page", results: []scriptedResult{firstPage, {body: `{"meta":{"code":500,"message":"SERVER_ERROR"}}`}}, lots: 1},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "")
+		bl.request = &scriptedRequest{results: tc.results}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if tc.cancel {
+			cancel()
+		}
+
+		var buf bytes.Buffer
+		err := bl.ExportInventory(ctx, &buf, FormatJSON)
+		cancel()
+		if err == nil {
+			t.Errorf("%v, want error, got nil\n", tc.desc)
+		}
+
+		var lots []Inventory
+		err = json.Unmarshal(buf.Bytes(), &lots)
+		if err != nil || len(lots) != tc.lots {
+			t.Errorf("%v, want valid JSON with %v lots, got: %v (%v)\n", tc.desc, tc.lots, buf.String(), err)
+		}
+	}
+}
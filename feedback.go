@@ -0,0 +1,221 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Rating is the rating of a feedback.
+type Rating int
+
+// Feedback ratings as used by BrickLink
+const (
+	RatingPraise    Rating = 0
+	RatingNeutral   Rating = 1
+	RatingComplaint Rating = 2
+)
+
+// Feedback is a feedback entry left for an order.
+type Feedback struct {
+	FeedbackID int       `json:"feedback_id"`
+	OrderID    int       `json:"order_id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	DateRated  time.Time `json:"date_rated"`
+	Rating     Rating    `json:"rating"`
+	// RatingOfBS is "S" for feedback on the seller and "B" for feedback on
+	// the buyer
+	RatingOfBS string `json:"rating_of_bs"`
+	Comment    string `json:"comment"`
+	Reply      string `json:"reply"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a feedback, parsing its timestamps leniently.
+func (f *Feedback) UnmarshalJSON(b []byte) error {
+	type alias Feedback
+	aux := struct {
+		*alias
+		DateRated timestamp `json:"date_rated"`
+	}{alias: (*alias)(f)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+	f.DateRated = time.Time(aux.DateRated)
+
+	return nil
+}
+
+// GetFeedbackList issues a GET request to the Bricklink API and querys for the
+// feedback received or left. Params are passed on as query parameters (e.g.
+// direction "in" or "out").
+func (bl Bricklink) GetFeedbackList(params map[string]string) (response string, err error) {
+	body, err := bl.GetFeedbackListBytes(params)
+	return string(body), err
+}
+
+// GetFeedbackListBytes is like GetFeedbackList but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetFeedbackListBytes(params map[string]string) (response []byte, err error) {
+	body, err := bl.send(bl.context(), "GET", buildURI("/feedback", params), nil)
+	if err != nil {
+		return response, err
+	}
+
+	return body, nil
+}
+
+// GetFeedbackListParsed querys for the feedback received or left and returns
+// the parsed entries.
+func (bl Bricklink) GetFeedbackListParsed(params map[string]string) (feedback []Feedback, err error) {
+	err = bl.getParsed(bl.context(), buildURI("/feedback", params), &feedback)
+	return feedback, err
+}
+
+// LeaveFeedbackForCompleted leaves feedback with the given comment and rating
+// for all completed orders received by the store which the seller hasn't
+// rated yet. It returns the number of orders feedback was left for; failures
+// are collected in a MultiError.
+//
+// An order counts as rated if it is in the list of feedback left, or, checked
+// right before posting, the order has a feedback on the buyer. The requests
+// are subject to the rate limit and concurrency limit of the handler. The
+// comment is validated and normalized like the text of lots.
+func (bl Bricklink) LeaveFeedbackForCompleted(ctx context.Context, comment string, rating Rating) (int, error) {
+	comment, err := bl.prepareText("comment", comment)
+	if err != nil {
+		return 0, err
+	}
+
+	var orders []Order
+	err = bl.getParsed(ctx, buildURI("/orders", map[string]string{"direction": "in", "status": "COMPLETED"}), &orders)
+	if err != nil {
+		return 0, err
+	}
+
+	// the list of feedback left may be outdated when cached, so bypass the cache
+	body, err := bl.send(ctx, "GET", buildURI("/feedback", map[string]string{"direction": "out"}), nil)
+	if err != nil {
+		return 0, err
+	}
+	var left []Feedback
+	err = bl.decode(body, &left)
+	if err != nil {
+		return 0, err
+	}
+
+	rated := make(map[int]bool, len(left))
+	for _, f := range left {
+		rated[f.OrderID] = true
+	}
+
+	var pending []int
+	for _, o := range orders {
+		if !rated[o.OrderID] {
+			pending = append(pending, o.OrderID)
+		}
+	}
+
+	var posted int32
+	errs, _ := runBatch(ctx, len(pending), bl.batchOptions(nil), func(ctx context.Context, i int) error {
+		ok, err := bl.leaveFeedback(ctx, pending[i], comment, rating)
+		if ok {
+			atomic.AddInt32(&posted, 1)
+		}
+		return err
+	})
+
+	var merr MultiError
+	for i, err := range errs {
+		if err != nil {
+			merr = append(merr, fmt.Errorf("order %v: %w", pending[i], err))
+		}
+	}
+
+	return int(posted), merr.errOrNil()
+}
+
+// leaveFeedback posts feedback on the buyer of an order unless the order
+// already has one. It reports whether feedback was posted.
+func (bl Bricklink) leaveFeedback(ctx context.Context, orderID int, comment string, rating Rating) (bool, error) {
+	body, err := bl.send(ctx, "GET", "/orders/"+strconv.Itoa(orderID)+"/feedback", nil)
+	if err != nil {
+		return false, err
+	}
+	var existing []Feedback
+	err = bl.decode(body, &existing)
+	if err != nil {
+		return false, err
+	}
+	for _, f := range existing {
+		if strings.EqualFold(f.RatingOfBS, "B") {
+			return false, nil
+		}
+	}
+
+	payload, err := json.Marshal(struct {
+		OrderID int    `json:"order_id"`
+		Rating  Rating `json:"rating"`
+		Comment string `json:"comment"`
+	}{orderID, rating, comment})
+	if err != nil {
+		return false, err
+	}
+
+	body, err = bl.send(ctx, "POST", "/feedback", payload)
+	if err != nil {
+		return false, err
+	}
+
+	err = bl.decode(body, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// FeedbackSummary counts feedback by rating.
+type FeedbackSummary struct {
+	Total     int
+	Praise    int
+	Neutral   int
+	Complaint int
+
+	// NegativeRatio is the share of complaints in Total, 0 if Total is 0
+	NegativeRatio float64
+}
+
+// SummarizeFeedback counts the feedback by rating, e.g. to screen buyers
+// before accepting an order.
+func SummarizeFeedback(feedback []Feedback) FeedbackSummary {
+	var s FeedbackSummary
+	for _, f := range feedback {
+		switch f.Rating {
+		case RatingPraise:
+			s.Praise++
+		case RatingNeutral:
+			s.Neutral++
+		case RatingComplaint:
+			s.Complaint++
+		default:
+			continue
+		}
+		s.Total++
+	}
+
+	if s.Total > 0 {
+		s.NegativeRatio = float64(s.Complaint) / float64(s.Total)
+	}
+
+	return s
+}
@@ -0,0 +1,53 @@
+package bricklinkapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLeaveFeedbackForCompleted(t *testing.T) {
+	// orders 1 to 3 are completed, order 1 is rated in the feedback list and
+	// order 2 on the order only
+	r := &recordingRequest{routes: routeRequest{
+		"/orders?":           `{"meta":{"code":200},"data":[{"order_id":1},{"order_id":2},{"order_id":3}]}`,
+		"/feedback":          `{"meta":{"code":200},"data":[{"order_id":1,"rating_of_bs":"B"}]}`,
+		"/orders/2/feedback": `{"meta":{"code":200},"data":[{"order_id":2,"rating_of_bs":"S"},{"order_id":2,"rating_of_bs":"B"}]}`,
+		"/orders/3/feedback": `{"meta":{"code":200},"data":[{"order_id":3,"rating_of_bs":"S"}]}`,
+	}}
+	bl := New("", "", "", "")
+	bl.request = r
+
+	n, err := bl.LeaveFeedbackForCompleted(context.Background(), "Thanks!", RatingPraise)
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	want := `{"order_id":3,"rating":0,"comment":"Thanks!"}`
+	if n != 1 || len(r.payloads) != 1 || r.payloads[0] != want {
+		t.Errorf("\nwant: %v\ngot: %v %v\n", want, n, r.payloads)
+	}
+}
+
+func TestSummarizeFeedback(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		ratings []Rating
+		exp     FeedbackSummary
+	}{
+		{desc: "testing no feedback", ratings: nil, exp: FeedbackSummary{}},
+		{desc: "testing mixed ratings", ratings: []Rating{RatingPraise, RatingPraise, RatingNeutral, RatingComplaint},
+			exp: FeedbackSummary{Total: 4, Praise: 2, Neutral: 1, Complaint: 1, NegativeRatio: 0.25}},
+		{desc: "testing unknown rating", ratings: []Rating{RatingComplaint, 7},
+			exp: FeedbackSummary{Total: 1, Complaint: 1, NegativeRatio: 1}},
+	}
+	for _, tc := range testCases {
+		var feedback []Feedback
+		for _, r := range tc.ratings {
+			feedback = append(feedback, Feedback{Rating: r})
+		}
+
+		result := SummarizeFeedback(feedback)
+		if result != tc.exp {
+			t.Errorf("%v, want: %+v, got: %+v\n", tc.desc, tc.exp, result)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package bricklinkapi
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// FeeSchedule is the list of fees charged on an order, e.g. the BrickLink
+// commission and the payment provider fee. Fee schedules change over time
+// and differ by account, so they are supplied by the caller; the package
+// doesn't ship any rates.
+type FeeSchedule []Fee
+
+// Fee is a single fee charged on an order. The fee is the percentage of its
+// base, or the sum of the tiers if tiers are set, plus the fixed amount,
+// limited to Cap. All amounts must be in the currency of the order.
+type Fee struct {
+	Name string
+
+	// OnSubtotal charges the fee on the subtotal (items only) instead of
+	// the grand total
+	OnSubtotal bool
+
+	// Percent is the percentage of the base charged, e.g. 3.49 for 3.49%
+	Percent float64
+	// Tiers charge different percentages on consecutive parts of the base,
+	// replacing Percent
+	Tiers []FeeTier
+
+	Fixed Money
+	// Cap is the maximum of the fee, 0 for no maximum
+	Cap Money
+}
+
+// FeeTier charges Percent on the part of the base up to UpTo, starting
+// where the previous tier ended. An UpTo of 0 covers the rest of the base.
+type FeeTier struct {
+	UpTo    Money
+	Percent float64
+}
+
+// EstimateNet returns the grand total of the order minus the fees, i.e. the
+// estimated net proceeds. Fees are rounded to the smallest unit of Money.
+func EstimateNet(order Order, fees FeeSchedule) (Money, error) {
+	currency := order.Cost.CurrencyCode
+	net := Money{Amount: order.Cost.GrandTotal.Amount, Currency: currency}
+
+	for _, f := range fees {
+		amount, err := f.amount(order, currency)
+		if err != nil {
+			return Money{}, fmt.Errorf("fee %v: %v", f.Name, err)
+		}
+		net.Amount -= amount
+	}
+
+	return net, nil
+}
+
+// amount returns the fee charged on the order
+func (f Fee) amount(order Order, currency string) (int64, error) {
+	for _, m := range append([]Money{f.Fixed, f.Cap}, tierLimits(f.Tiers)...) {
+		if m.Currency != "" && currency != "" && !strings.EqualFold(m.Currency, currency) {
+			return 0, fmt.Errorf("currency %v doesn't match the order currency %v", m.Currency, currency)
+		}
+	}
+
+	base := order.Cost.GrandTotal.Amount
+	if f.OnSubtotal {
+		base = order.Cost.Subtotal.Amount
+	}
+
+	var fee float64
+	if len(f.Tiers) == 0 {
+		if f.Percent < 0 {
+			return 0, fmt.Errorf("percentage %v is negative", f.Percent)
+		}
+		fee = float64(base) * f.Percent / 100
+	}
+
+	var from int64
+	for i, t := range f.Tiers {
+		if t.Percent < 0 {
+			return 0, fmt.Errorf("percentage %v of tier %v is negative", t.Percent, i)
+		}
+		to := t.UpTo.Amount
+		if to == 0 || to > base {
+			to = base
+		}
+		if to > from {
+			fee += float64(to-from) * t.Percent / 100
+			from = to
+		}
+	}
+
+	amount := int64(math.Round(fee)) + f.Fixed.Amount
+	if f.Cap.Amount > 0 && amount > f.Cap.Amount {
+		amount = f.Cap.Amount
+	}
+
+	return amount, nil
+}
+
+// helper function to collect the limits of fee tiers
+func tierLimits(tiers []FeeTier) []Money {
+	limits := make([]Money, len(tiers))
+	for i, t := range tiers {
+		limits[i] = t.UpTo
+	}
+	return limits
+}
@@ -0,0 +1,42 @@
+package bricklinkapi
+
+import (
+	"testing"
+)
+
+func TestEstimateNet(t *testing.T) {
+	order := Order{Cost: OrderCost{
+		CurrencyCode: "USD",
+		Subtotal:     Money{Amount: 8000000},
+		GrandTotal:   Money{Amount: 9000000},
+	}}
+
+	testCases := []struct {
+		desc string
+		fees FeeSchedule
+		exp  int64
+		err  bool
+	}{
+		{desc: "testing no fees", fees: nil, exp: 9000000},
+		{desc: "testing percent and fixed", fees: FeeSchedule{{Percent: 3.49, Fixed: Money{Amount: 4900}}}, exp: 9000000 - 314100 - 4900},
+		{desc: "testing tiered on subtotal", fees: FeeSchedule{{OnSubtotal: true, Tiers: []FeeTier{
+			{UpTo: Money{Amount: 5000000}, Percent: 3},
+			{Percent: 2},
+		}}}, exp: 9000000 - 150000 - 60000},
+		{desc: "testing capped", fees: FeeSchedule{{Percent: 10, Cap: Money{Amount: 100000}}}, exp: 8900000},
+		{desc: "testing currency mismatch", fees: FeeSchedule{{Fixed: Money{Amount: 1, Currency: "EUR"}}}, err: true},
+		{desc: "testing negative", fees: FeeSchedule{{Percent: -1}}, err: true},
+	}
+	for _, tc := range testCases {
+		net, err := EstimateNet(order, tc.fees)
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if tc.err {
+			continue
+		}
+		if net.Amount != tc.exp || net.Currency != "USD" {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.exp, net)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package bricklinkapi
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// helper function to block until the request handler got n requests or, if
+// coalescing, until n callers joined the call for key
+func awaitCallers(bl *Bricklink, f *fakeRequest, key string, n int) {
+	for {
+		var callers int
+		if bl.flight != nil {
+			bl.flight.mu.Lock()
+			if c, ok := bl.flight.calls[key]; ok {
+				callers = 1 + c.dups
+			}
+			bl.flight.mu.Unlock()
+		} else {
+			f.mu.Lock()
+			callers = f.calls
+			f.mu.Unlock()
+		}
+		if callers >= n {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestRequestCoalescing(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		opts     []Option
+		requests int
+	}{
+		{desc: "testing with coalescing", opts: []Option{WithRequestCoalescing()}, requests: 1},
+		{desc: "testing without coalescing", opts: nil, requests: 5},
+	}
+	for _, tc := range testCases {
+		f := &fakeRequest{
+			body:    []byte(`{"meta":{"code":200},"data":{"no":"3001","type":"PART"}}`),
+			release: make(chan struct{}),
+		}
+		bl := New("", "", "", "", tc.opts...)
+		bl.request = f
+
+		var wg sync.WaitGroup
+		items := make([]CatalogItem, 5)
+		errs := make([]error, 5)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				items[i], errs[i] = bl.GetItemParsed("PART", "3001")
+			}(i)
+		}
+
+		// release the request only once all calls reached the handler or
+		// joined the call in flight
+		awaitCallers(bl, f, "/items/PART/3001", 5)
+		close(f.release)
+		wg.Wait()
+
+		for i := range items {
+			if errs[i] != nil || items[i].No != "3001" {
+				t.Errorf("%v, unexpected result: %+v (%v)\n", tc.desc, items[i], errs[i])
+			}
+		}
+		if f.calls != tc.requests {
+			t.Errorf("%v, want: %v requests, got: %v\n", tc.desc, tc.requests, f.calls)
+		}
+	}
+}
+
+func TestFlightGroupCancel(t *testing.T) {
+	g := newFlightGroup()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go g.do(context.Background(), "k", func() ([]byte, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := g.do(ctx, "k", func() ([]byte, error) {
+		t.Errorf("\nunexpected second call\n")
+		return nil, nil
+	})
+	if err != context.Canceled {
+		t.Errorf("\nwant: %v, got: %v\n", context.Canceled, err)
+	}
+	close(release)
+}
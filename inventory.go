@@ -0,0 +1,242 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Inventory is a single lot of the store inventory.
+type Inventory struct {
+	InventoryID int    `json:"inventory_id"`
+	Item        Item   `json:"item"`
+	ColorID     int    `json:"color_id"`
+	ColorName   string `json:"color_name"`
+	Quantity    int    `json:"quantity"`
+	NewOrUsed   string `json:"new_or_used"`
+	UnitPrice   Money  `json:"unit_price"`
+	Description string `json:"description"`
+	Remarks     string `json:"remarks"`
+	MyCost      Money  `json:"my_cost"`
+	Bulk        int    `json:"bulk"`
+
+	// Completeness is set for sets only: "C" complete, "B" incomplete or
+	// "S" sealed
+	Completeness string `json:"completeness"`
+
+	// SaleRate is the discount of the lot in percent, see EffectivePrice
+	SaleRate int `json:"sale_rate"`
+
+	// IsRetain is set if the lot is kept once it is sold out
+	IsRetain bool `json:"is_retain"`
+
+	// IsStockRoom is set for lots in a stockroom, which are not for sale
+	IsStockRoom bool `json:"is_stock_room"`
+
+	// DateCreated is when the lot was created. BrickLink doesn't report
+	// when a lot was last modified.
+	DateCreated time.Time `json:"date_created"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a lot, parsing its timestamps leniently.
+func (inv *Inventory) UnmarshalJSON(b []byte) error {
+	type alias Inventory
+	aux := struct {
+		*alias
+		DateCreated timestamp `json:"date_created"`
+	}{alias: (*alias)(inv)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+	inv.DateCreated = time.Time(aux.DateCreated)
+
+	return nil
+}
+
+// EffectivePrice returns the unit price buyers see, i.e. the unit price with
+// the sale rate applied, rounded to the smallest unit of Money. Sale rates
+// outside of 0 to 100 percent are ignored.
+func (inv Inventory) EffectivePrice() Money {
+	price := inv.UnitPrice
+	if inv.SaleRate <= 0 || inv.SaleRate > 100 {
+		return price
+	}
+	price.Amount = int64(math.Round(float64(price.Amount) * float64(100-inv.SaleRate) / 100))
+	return price
+}
+
+// CreatedSince returns the lots created after t, e.g. the lots added since
+// the last sync. As BrickLink only reports the creation date, changes to
+// existing lots are not detected; use PriceQuantityDelta for those.
+func CreatedSince(inventories []Inventory, t time.Time) []Inventory {
+	var created []Inventory
+	for _, inv := range inventories {
+		if inv.DateCreated.After(t) {
+			created = append(created, inv)
+		}
+	}
+	return created
+}
+
+// GetInventoryList issues a GET request to the Bricklink API and querys for the
+// store inventory. Params are passed on as query parameters (e.g. item_type, status).
+func (bl Bricklink) GetInventoryList(params map[string]string) (response string, err error) {
+	body, err := bl.GetInventoryListBytes(params)
+	return string(body), err
+}
+
+// GetInventoryListBytes is like GetInventoryList but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetInventoryListBytes(params map[string]string) (response []byte, err error) {
+	body, err := bl.send(bl.context(), "GET", buildURI("/inventories", params), nil)
+	if err != nil {
+		return response, err
+	}
+
+	return body, nil
+}
+
+// GetInventoryListParsed querys for the store inventory and returns the parsed lots.
+func (bl Bricklink) GetInventoryListParsed(params map[string]string) (inventories []Inventory, err error) {
+	return bl.inventories(bl.context(), params)
+}
+
+func (bl Bricklink) inventories(ctx context.Context, params map[string]string) (inventories []Inventory, err error) {
+	err = bl.getParsed(ctx, buildURI("/inventories", params), &inventories)
+	return inventories, err
+}
+
+// FindInventoryLot searches the store inventory for a lot of the given item,
+// color and condition ("N" or "U"). It returns nil if no such lot exists, so
+// callers can merge into an existing lot instead of creating a duplicate.
+func (bl Bricklink) FindInventoryLot(item Item, colorID int, condition string) (*Inventory, error) {
+	inventories, err := bl.GetInventoryListParsed(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, inv := range inventories {
+		if sameLot(inv, item, colorID, condition) {
+			return &inventories[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindDuplicateLots fetches the store inventory and returns the groups of lots
+// sharing item, color and condition, i.e. lots which could be merged. Only
+// groups of more than one lot are returned. Consolidating them is left to the
+// caller.
+func (bl Bricklink) FindDuplicateLots(ctx context.Context) ([][]Inventory, error) {
+	inventories, err := bl.inventories(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicateLots(inventories), nil
+}
+
+// LowStockOption configures LowStockLots.
+type LowStockOption func(*lowStockOptions)
+
+type lowStockOptions struct {
+	stockroom  bool
+	thresholds map[string]int
+}
+
+// LowStockIncludeStockroom makes LowStockLots include stockroom lots.
+func LowStockIncludeStockroom() LowStockOption {
+	return func(o *lowStockOptions) {
+		o.stockroom = true
+	}
+}
+
+// LowStockItemThresholds sets thresholds per item, overriding the threshold
+// passed to LowStockLots for lots of these items. Items are matched by type
+// and number.
+func LowStockItemThresholds(thresholds map[Item]int) LowStockOption {
+	return func(o *lowStockOptions) {
+		for item, t := range thresholds {
+			o.thresholds[itemKey(item)] = t
+		}
+	}
+}
+
+// LowStockLots fetches the store inventory and returns the lots with a
+// quantity at or below threshold, e.g. for restock alerts. Stockroom lots
+// are left out unless LowStockIncludeStockroom is passed.
+func (bl Bricklink) LowStockLots(ctx context.Context, threshold int, opts ...LowStockOption) ([]Inventory, error) {
+	inventories, err := bl.inventories(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return lowStock(inventories, threshold, opts...), nil
+}
+
+// helper function to filter the lots at or below their threshold
+func lowStock(inventories []Inventory, threshold int, opts ...LowStockOption) []Inventory {
+	o := lowStockOptions{thresholds: make(map[string]int)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	low := []Inventory{}
+	for _, inv := range inventories {
+		if inv.IsStockRoom && !o.stockroom {
+			continue
+		}
+		t, ok := o.thresholds[itemKey(inv.Item)]
+		if !ok {
+			t = threshold
+		}
+		if inv.Quantity <= t {
+			low = append(low, inv)
+		}
+	}
+	return low
+}
+
+// helper function to group lots by item, color and condition, keeping the
+// groups with more than one lot in order of their first lot
+func duplicateLots(inventories []Inventory) [][]Inventory {
+	var keys []string
+	groups := make(map[string][]Inventory)
+	for _, inv := range inventories {
+		k := lotKey(inv)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], inv)
+	}
+
+	var duplicates [][]Inventory
+	for _, k := range keys {
+		if len(groups[k]) > 1 {
+			duplicates = append(duplicates, groups[k])
+		}
+	}
+	return duplicates
+}
+
+// helper function to build a map key identifying item, color and condition of a lot
+func lotKey(inv Inventory) string {
+	return itemKey(inv.Item) + "/" + strconv.Itoa(inv.ColorID) + "/" + strings.ToUpper(inv.NewOrUsed)
+}
+
+// helper function to check if an inventory lot matches item, color and condition
+func sameLot(inv Inventory, item Item, colorID int, condition string) bool {
+	return strings.EqualFold(inv.Item.Type, item.Type) &&
+		strings.EqualFold(inv.Item.No, item.No) &&
+		inv.ColorID == colorID &&
+		strings.EqualFold(inv.NewOrUsed, condition)
+}
@@ -0,0 +1,101 @@
+package bricklinkapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDuplicateLots(t *testing.T) {
+	inventories := []Inventory{
+		{InventoryID: 1, Item: Item{No: "3001", Type: "PART"}, ColorID: 1, NewOrUsed: "N"},
+		{InventoryID: 2, Item: Item{No: "3001", Type: "PART"}, ColorID: 1, NewOrUsed: "U"},
+		{InventoryID: 3, Item: Item{No: "3001", Type: "part"}, ColorID: 1, NewOrUsed: "n"},
+		{InventoryID: 4, Item: Item{No: "3002", Type: "PART"}, ColorID: 1, NewOrUsed: "N"},
+	}
+
+	groups := duplicateLots(inventories)
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("\nwant one group of two lots, got: %+v\n", groups)
+	}
+	if groups[0][0].InventoryID != 1 || groups[0][1].InventoryID != 3 {
+		t.Errorf("\nunexpected group: %+v\n", groups[0])
+	}
+}
+
+func TestDecodeInventoryDateCreated(t *testing.T) {
+	testCases := []struct {
+		desc string
+		date string
+		exp  time.Time
+		err  bool
+	}{
+		{desc: "testing RFC3339", date: `"2013-12-30T22:48:11.547Z"`, exp: time.Date(2013, 12, 30, 22, 48, 11, 547000000, time.UTC)},
+		{desc: "testing without zone", date: `"2013-12-30T22:48:11"`, exp: time.Date(2013, 12, 30, 22, 48, 11, 0, time.UTC)},
+		{desc: "testing empty", date: `""`},
+		{desc: "testing null", date: `null`},
+		{desc: "testing garbage", date: `"yesterday"`, err: true},
+	}
+	for _, tc := range testCases {
+		var inv Inventory
+		err := json.Unmarshal([]byte(`{"inventory_id":1,"date_created":`+tc.date+`}`), &inv)
+		if (err != nil) != tc.err {
+			t.Errorf("\n%v, unexpected error: %v\n", tc.desc, err)
+			continue
+		}
+		if !tc.err && (!inv.DateCreated.Equal(tc.exp) || inv.InventoryID != 1) {
+			t.Errorf("\n%v, want: %v, got: %v\n", tc.desc, tc.exp, inv.DateCreated)
+		}
+	}
+}
+
+func TestLowStock(t *testing.T) {
+	inventories := []Inventory{
+		{InventoryID: 1, Item: Item{No: "3001", Type: "PART"}, Quantity: 2},
+		{InventoryID: 2, Item: Item{No: "3002", Type: "PART"}, Quantity: 5},
+		{InventoryID: 3, Item: Item{No: "3003", Type: "PART"}, Quantity: 1, IsStockRoom: true},
+		{InventoryID: 4, Item: Item{No: "3004", Type: "PART"}, Quantity: 20},
+	}
+
+	testCases := []struct {
+		desc string
+		opts []LowStockOption
+		exp  []int
+	}{
+		{desc: "testing default", opts: nil, exp: []int{1}},
+		{desc: "testing stockroom", opts: []LowStockOption{LowStockIncludeStockroom()}, exp: []int{1, 3}},
+		{desc: "testing per item", opts: []LowStockOption{LowStockItemThresholds(map[Item]int{{No: "3001", Type: "part"}: 0, {No: "3004", Type: "PART"}: 50})}, exp: []int{4}},
+	}
+	for _, tc := range testCases {
+		var result []int
+		for _, inv := range lowStock(inventories, 2, tc.opts...) {
+			result = append(result, inv.InventoryID)
+		}
+		if fmt.Sprint(result) != fmt.Sprint(tc.exp) {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.exp, result)
+		}
+	}
+}
+
+func TestEffectivePrice(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		price    int64
+		saleRate int
+		exp      int64
+	}{
+		{desc: "testing no sale", price: 12345, saleRate: 0, exp: 12345},
+		{desc: "testing rounding", price: 12345, saleRate: 10, exp: 11111},
+		{desc: "testing quarter off", price: 10000, saleRate: 25, exp: 7500},
+		{desc: "testing full sale", price: 10000, saleRate: 100, exp: 0},
+		{desc: "testing negative rate", price: 10000, saleRate: -5, exp: 10000},
+	}
+	for _, tc := range testCases {
+		inv := Inventory{UnitPrice: Money{Amount: tc.price}, SaleRate: tc.saleRate}
+		result := inv.EffectivePrice()
+		if result.Amount != tc.exp {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.exp, result.Amount)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InventoryCreate holds the fields of a new inventory lot.
+type InventoryCreate struct {
+	Item        Item   `json:"item"`
+	ColorID     int    `json:"color_id"`
+	Quantity    int    `json:"quantity"`
+	NewOrUsed   string `json:"new_or_used"`
+	UnitPrice   Money  `json:"unit_price"`
+	Description string `json:"description,omitempty"`
+	Remarks     string `json:"remarks,omitempty"`
+	MyCost      *Money `json:"my_cost,omitempty"`
+
+	// Completeness can only be set for sets: "C" complete, "B" incomplete
+	// or "S" sealed
+	Completeness string `json:"completeness,omitempty"`
+
+	// Bulk makes buyers purchase the lot in multiples of it. Quantity must be
+	// a multiple of Bulk. 0 and 1 both mean no bulk and are not sent.
+	Bulk int `json:"bulk,omitempty"`
+}
+
+// MarshalJSON encodes the lot, omitting Bulk unless it is greater than 1.
+func (c InventoryCreate) MarshalJSON() ([]byte, error) {
+	type alias InventoryCreate
+	aux := struct {
+		alias
+		Bulk int `json:"bulk,omitempty"`
+	}{alias: alias(c)}
+	if c.Bulk > 1 {
+		aux.Bulk = c.Bulk
+	}
+
+	return json.Marshal(aux)
+}
+
+// validate checks the fields of the lot depending on each other and its
+// free text fields
+func (c InventoryCreate) validate() error {
+	err := validateCompleteness(c.Item.Type, c.Completeness)
+	if err != nil {
+		return err
+	}
+
+	err = validateText("description", c.Description)
+	if err != nil {
+		return err
+	}
+	err = validateText("remarks", c.Remarks)
+	if err != nil {
+		return err
+	}
+
+	return validateBulk(c.Quantity, c.Bulk)
+}
+
+// helper function to validate the completeness of a lot, which is optional
+// and only valid for sets
+func validateCompleteness(itemType, completeness string) error {
+	if completeness == "" {
+		return nil
+	}
+	if !strings.EqualFold(itemType, "SET") {
+		return fmt.Errorf("completeness is only valid for sets, not %v", itemType)
+	}
+	return validateParam(completeness, completenessValues)
+}
+
+// helper function to validate the bulk of a lot, a bulk of 0 is not set
+func validateBulk(quantity, bulk int) error {
+	if bulk < 0 {
+		return fmt.Errorf("bulk must be at least 1, got %v", bulk)
+	}
+	if bulk > 1 && quantity%bulk != 0 {
+		return fmt.Errorf("quantity %v is not a multiple of bulk %v", quantity, bulk)
+	}
+	return nil
+}
+
+// CreateResult reports the outcome of CreateInventories. Passing Remaining
+// to CreateInventories again resumes the upload without creating a lot twice.
+type CreateResult struct {
+	// Created holds the created lots, including their new inventory IDs, in
+	// the order of the input
+	Created []Inventory
+	// Remaining holds the lots which were not created, in the order of the
+	// input
+	Remaining []InventoryCreate
+}
+
+// CreateInventory issues a POST request to the Bricklink API and creates a
+// new inventory lot. The created lot is returned. Descriptions and remarks
+// containing control characters are rejected, unless WithTextNormalization
+// is set.
+func (bl Bricklink) CreateInventory(ctx context.Context, lot InventoryCreate) (inventory Inventory, err error) {
+	lot, err = bl.prepareLot(lot)
+	if err != nil {
+		return inventory, err
+	}
+
+	payload, err := json.Marshal(lot)
+	if err != nil {
+		return inventory, err
+	}
+
+	body, err := bl.send(ctx, "POST", "/inventories", payload)
+	if err != nil {
+		return inventory, err
+	}
+
+	err = bl.decode(body, &inventory)
+	return inventory, err
+}
+
+// prepareLot normalizes the text of the lot if enabled and validates it
+func (bl Bricklink) prepareLot(lot InventoryCreate) (InventoryCreate, error) {
+	if bl.normalizeText {
+		lot.Description = normalizeText(lot.Description)
+		lot.Remarks = normalizeText(lot.Remarks)
+	}
+
+	if bl.skipValidation {
+		return lot, nil
+	}
+	return lot, lot.validate()
+}
+
+// UpdateInventory issues a PUT request to the Bricklink API and applies the
+// update to the lot with update.InventoryID. The updated lot is returned.
+func (bl Bricklink) UpdateInventory(ctx context.Context, update InventoryUpdate) (inventory Inventory, err error) {
+	update.Description, err = bl.prepareText("description", update.Description)
+	if err != nil {
+		return inventory, err
+	}
+	update.Remarks, err = bl.prepareText("remarks", update.Remarks)
+	if err != nil {
+		return inventory, err
+	}
+
+	if !bl.skipValidation {
+		err = validateBulk(0, update.Bulk)
+		if err != nil {
+			return inventory, err
+		}
+		if update.Completeness != "" {
+			err = validateParam(update.Completeness, completenessValues)
+			if err != nil {
+				return inventory, err
+			}
+		}
+	}
+
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return inventory, err
+	}
+
+	body, err := bl.send(ctx, "PUT", "/inventories/"+strconv.Itoa(update.InventoryID), payload)
+	if err != nil {
+		return inventory, err
+	}
+
+	err = bl.decode(body, &inventory)
+	return inventory, err
+}
+
+// RetainInventory sets whether the lot is kept in the inventory once it is
+// sold out (retain true) or deleted (retain false).
+func (bl Bricklink) RetainInventory(ctx context.Context, inventoryID int, retain bool) error {
+	_, err := bl.UpdateInventory(ctx, InventoryUpdate{InventoryID: inventoryID, IsRetain: &retain})
+	return err
+}
+
+// DecrementInventory lowers the quantity of the lot by the given amount,
+// e.g. for the items of a new order. The lot is fetched first and an error is
+// returned if its quantity is lower than by; otherwise the quantity is
+// updated relatively, so calls for different lots can run concurrently.
+// Concurrent calls for the same lot are not checked against each other.
+func (bl Bricklink) DecrementInventory(ctx context.Context, inventoryID, by int) error {
+	if by <= 0 {
+		return fmt.Errorf("decrement must be positive, got %v", by)
+	}
+
+	inv, err := bl.freshInventory(ctx, inventoryID)
+	if err != nil {
+		return err
+	}
+	if inv.Quantity < by {
+		return fmt.Errorf("lot %v has quantity %v, can't decrement by %v", inventoryID, inv.Quantity, by)
+	}
+
+	_, err = bl.UpdateInventory(ctx, InventoryUpdate{InventoryID: inventoryID, Quantity: -by})
+	return err
+}
+
+// freshInventory fetches a lot bypassing the cache
+func (bl Bricklink) freshInventory(ctx context.Context, inventoryID int) (inventory Inventory, err error) {
+	body, err := bl.send(ctx, "GET", "/inventories/"+strconv.Itoa(inventoryID), nil)
+	if err != nil {
+		return inventory, err
+	}
+
+	err = bl.decode(body, &inventory)
+	return inventory, err
+}
+
+// CreateInventories creates the lots concurrently, one request per lot. The
+// returned result tells which lots were created and which remain, the error
+// is a MultiError of the failed lots.
+//
+// With WithVerifiedWrites, lots whose request failed ambiguously, i.e. may
+// have reached BrickLink, are looked up in the store inventory after the
+// upload. A lot created during the upload with matching item, color,
+// condition, quantity and price is reported as created instead of remaining.
+// The lookup costs one extra GET request per page of the inventory.
+func (bl Bricklink) CreateInventories(ctx context.Context, lots []InventoryCreate, opts ...BatchOption) (CreateResult, error) {
+	start := time.Now()
+
+	created := make([]*Inventory, len(lots))
+	errs, _ := runBatch(ctx, len(lots), bl.batchOptions(opts), func(ctx context.Context, i int) error {
+		inv, err := bl.CreateInventory(ctx, lots[i])
+		if err != nil {
+			return err
+		}
+		created[i] = &inv
+		return nil
+	})
+
+	if bl.verifyWrites {
+		bl.verifyCreated(ctx, lots, created, errs, start)
+	}
+
+	var result CreateResult
+	var merr MultiError
+	for i, lot := range lots {
+		if created[i] != nil {
+			result.Created = append(result.Created, *created[i])
+			continue
+		}
+		result.Remaining = append(result.Remaining, lot)
+		merr = append(merr, fmt.Errorf("lot %v %v %v: %v", i, lot.Item.Type, lot.Item.No, errs[i]))
+	}
+
+	return result, merr.errOrNil()
+}
+
+// verifyCreated looks up the lots which failed with a transport error in the
+// store inventory and sets them in created if found. Lots created before
+// start or already matched are not considered.
+func (bl Bricklink) verifyCreated(ctx context.Context, lots []InventoryCreate, created []*Inventory, errs []error, start time.Time) {
+	var ambiguous []int
+	for i, err := range errs {
+		var te *transportError
+		if errors.As(err, &te) {
+			ambiguous = append(ambiguous, i)
+		}
+	}
+	if len(ambiguous) == 0 {
+		return
+	}
+
+	// fetch the whole inventory bypassing the cache
+	var inventories []Inventory
+	if bl.getAll(ctx, "/inventories", nil, &inventories) != nil {
+		return
+	}
+
+	claimed := make(map[int]bool)
+	for _, inv := range created {
+		if inv != nil {
+			claimed[inv.InventoryID] = true
+		}
+	}
+
+	// BrickLink reports creation dates with minute precision at best
+	since := start.Truncate(time.Minute)
+	for _, i := range ambiguous {
+		for j, inv := range inventories {
+			if claimed[inv.InventoryID] || inv.DateCreated.Before(since) || !createdAs(inv, lots[i]) {
+				continue
+			}
+			claimed[inv.InventoryID] = true
+			created[i] = &inventories[j]
+			break
+		}
+	}
+}
+
+// helper function to check if a lot matches the fields it was created with
+func createdAs(inv Inventory, lot InventoryCreate) bool {
+	return sameLot(inv, lot.Item, lot.ColorID, lot.NewOrUsed) &&
+		inv.Quantity == lot.Quantity &&
+		inv.UnitPrice.Amount == lot.UnitPrice.Amount
+}
+
+// Outcomes of UpsertInventory
+const (
+	UpsertCreated = "created"
+	UpsertUpdated = "updated"
+)
+
+// UpsertOption configures UpsertInventory.
+type UpsertOption func(*upsertOptions)
+
+type upsertOptions struct {
+	match func(existing Inventory, lot InventoryCreate) bool
+}
+
+// UpsertMatching replaces the criteria an existing lot has to meet to be
+// updated by UpsertInventory, by default the same item, color and condition.
+// With custom criteria the whole store inventory is searched, not only the
+// lots of the item type.
+func UpsertMatching(match func(existing Inventory, lot InventoryCreate) bool) UpsertOption {
+	return func(o *upsertOptions) {
+		o.match = match
+	}
+}
+
+// UpsertInventory updates the existing lot matching the given one, or
+// creates it if there is none. The quantity of lot is added to the existing
+// lot, while its unit price is replaced by the one of lot; a lot without a
+// unit price (the zero Money) keeps the existing price. It returns
+// UpsertCreated or UpsertUpdated; if more than one lot matches nothing is
+// written and an error is returned.
+//
+// The store inventory is fetched bypassing the cache, following its
+// pagination, but the check and the write are not atomic: concurrent upserts
+// of the same lot, e.g. from two imports, may both create it.
+func (bl Bricklink) UpsertInventory(ctx context.Context, lot InventoryCreate, opts ...UpsertOption) (string, error) {
+	var o upsertOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	lot, err := bl.prepareLot(lot)
+	if err != nil {
+		return "", err
+	}
+
+	// the default criteria include the item type, so only the lots of that
+	// type need to be searched
+	var params map[string]string
+	if o.match == nil {
+		o.match = func(existing Inventory, lot InventoryCreate) bool {
+			return sameLot(existing, lot.Item, lot.ColorID, lot.NewOrUsed)
+		}
+		params = map[string]string{"item_type": lot.Item.Type}
+	}
+
+	var inventories []Inventory
+	err = bl.getAll(ctx, "/inventories", params, &inventories)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []Inventory
+	for _, inv := range inventories {
+		if o.match(inv, lot) {
+			matches = append(matches, inv)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		_, err = bl.CreateInventory(ctx, lot)
+		if err != nil {
+			return "", err
+		}
+		return UpsertCreated, nil
+	case 1:
+		update := InventoryUpdate{InventoryID: matches[0].InventoryID, Quantity: lot.Quantity}
+		if lot.UnitPrice != (Money{}) {
+			price := lot.UnitPrice
+			update.UnitPrice = &price
+		}
+		_, err = bl.UpdateInventory(ctx, update)
+		if err != nil {
+			return "", err
+		}
+		return UpsertUpdated, nil
+	}
+
+	return "", fmt.Errorf("%v lots match %v %v color %v", len(matches), lot.Item.Type, lot.Item.No, lot.ColorID)
+}
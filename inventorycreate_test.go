@@ -0,0 +1,260 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// uploadRequest is a request handler creating lots, failing the creation of
+// lots whose description is "fail" or "lost", the latter after creating it.
+// With a pageSize the inventory is listed in pages of that many lots.
+type uploadRequest struct {
+	lots     []string
+	pageSize int
+}
+
+func (u *uploadRequest) Request(method, uri string) ([]byte, error) {
+	return u.requestContext(context.Background(), method, uri, nil)
+}
+
+func (u *uploadRequest) requestContext(ctx context.Context, method, uri string, payload []byte) ([]byte, error) {
+	if method == "GET" {
+		if u.pageSize == 0 {
+			return []byte(`{"meta":{"code":200},"data":[` + strings.Join(u.lots, ",") + `]}`), nil
+		}
+		start := 0
+		if i := strings.Index(uri, "cursor="); i >= 0 {
+			start, _ = strconv.Atoi(uri[i+len("cursor="):])
+		}
+		end, cursor := start+u.pageSize, ""
+		if end < len(u.lots) {
+			cursor = strconv.Itoa(end)
+		} else {
+			end = len(u.lots)
+		}
+		return []byte(`{"meta":{"code":200,"next_cursor":"` + cursor + `"},"data":[` + strings.Join(u.lots[start:end], ",") + `]}`), nil
+	}
+
+	p := string(payload)
+	switch {
+	case strings.Contains(p, `"description":"fail"`):
+		return nil, &transportError{errors.New("connection reset")}
+	case strings.Contains(p, `"description":"lost"`):
+		u.lots = append(u.lots, `{"inventory_id":99,"item":{"no":"3002","type":"PART"},"color_id":1,"quantity":2,"new_or_used":"N","unit_price":"0.5000","date_created":"`+time.Now().UTC().Format(time.RFC3339)+`"}`)
+		return nil, &transportError{errors.New("connection reset")}
+	}
+	return []byte(`{"meta":{"code":201},"data":{"inventory_id":1}}`), nil
+}
+
+func TestCreateInventories(t *testing.T) {
+	lots := []InventoryCreate{
+		{Item: Item{No: "3001", Type: "PART"}, ColorID: 1, Quantity: 1, NewOrUsed: "N"},
+		{Item: Item{No: "3002", Type: "PART"}, ColorID: 1, Quantity: 2, NewOrUsed: "N", UnitPrice: Money{Amount: 5000}, Description: "lost"},
+		{Item: Item{No: "3003", Type: "PART"}, ColorID: 1, Quantity: 3, NewOrUsed: "N", Description: "fail"},
+	}
+
+	existing := `{"inventory_id":50,"item":{"no":"3005","type":"PART"},"color_id":1,"quantity":1,"new_or_used":"N","date_created":"2020-01-01T00:00:00Z"}`
+
+	testCases := []struct {
+		desc      string
+		opts      []Option
+		upload    *uploadRequest
+		created   int
+		remaining []string
+	}{
+		{desc: "testing unverified", opts: nil, upload: &uploadRequest{}, created: 1, remaining: []string{"3002", "3003"}},
+		{desc: "testing verified", opts: []Option{WithVerifiedWrites()}, upload: &uploadRequest{}, created: 2, remaining: []string{"3003"}},
+		{desc: "testing verified with the lot on the second page", opts: []Option{WithVerifiedWrites()},
+			upload: &uploadRequest{lots: []string{existing}, pageSize: 1}, created: 2, remaining: []string{"3003"}},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "", tc.opts...)
+		bl.request = tc.upload
+
+		result, err := bl.CreateInventories(context.Background(), lots)
+		if err == nil {
+			t.Errorf("%v, expected error\n", tc.desc)
+		}
+		if len(result.Created) != tc.created {
+			t.Errorf("%v, want: %v created, got: %v\n", tc.desc, tc.created, len(result.Created))
+		}
+		var remaining []string
+		for _, lot := range result.Remaining {
+			remaining = append(remaining, lot.Item.No)
+		}
+		if strings.Join(remaining, ",") != strings.Join(tc.remaining, ",") {
+			t.Errorf("%v, want remaining: %v, got: %v\n", tc.desc, tc.remaining, remaining)
+		}
+	}
+}
+
+func TestInventoryCreateBulk(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		quantity int
+		bulk     int
+		expS     string
+		err      bool
+	}{
+		{desc: "testing no bulk", quantity: 10, bulk: 0, expS: `{"item":{"no":"","name":"","type":"","category_id":0},"color_id":0,"quantity":10,"new_or_used":"","unit_price":"0.0000"}`},
+		{desc: "testing bulk of 1", quantity: 10, bulk: 1, expS: `{"item":{"no":"","name":"","type":"","category_id":0},"color_id":0,"quantity":10,"new_or_used":"","unit_price":"0.0000"}`},
+		{desc: "testing bulk", quantity: 10, bulk: 5, expS: `{"item":{"no":"","name":"","type":"","category_id":0},"color_id":0,"quantity":10,"new_or_used":"","unit_price":"0.0000","bulk":5}`},
+		{desc: "testing quantity not a multiple", quantity: 10, bulk: 3, err: true},
+		{desc: "testing negative bulk", quantity: 10, bulk: -1, err: true},
+	}
+	for _, tc := range testCases {
+		lot := InventoryCreate{Quantity: tc.quantity, Bulk: tc.bulk}
+		err := lot.validate()
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if tc.err {
+			continue
+		}
+
+		b, err := json.Marshal(lot)
+		if err != nil {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if string(b) != tc.expS {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expS, string(b))
+		}
+	}
+}
+
+func TestInventoryCreateCompleteness(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		itemType     string
+		completeness string
+		err          bool
+	}{
+		{desc: "testing set without completeness", itemType: "SET", completeness: ""},
+		{desc: "testing sealed set", itemType: "SET", completeness: "S"},
+		{desc: "testing invalid completeness", itemType: "SET", completeness: "X", err: true},
+		{desc: "testing part", itemType: "PART", completeness: ""},
+		{desc: "testing part with completeness", itemType: "PART", completeness: "C", err: true},
+	}
+	for _, tc := range testCases {
+		lot := InventoryCreate{Item: Item{No: "1", Type: tc.itemType}, Completeness: tc.completeness}
+		err := lot.validate()
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+	}
+}
+
+func TestRetainInventory(t *testing.T) {
+	for _, retain := range []bool{true, false} {
+		f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"inventory_id":7}}`)}
+		bl := New("", "", "", "")
+		bl.request = f
+
+		err := bl.RetainInventory(context.Background(), 7, retain)
+		if err != nil {
+			t.Errorf("\nunexpected error: %v\n", err)
+		}
+		want := `{"is_retain":` + strconv.FormatBool(retain) + `}`
+		if f.method != "PUT" || f.uri != "/inventories/7" || string(f.payload) != want {
+			t.Errorf("\nwant: PUT /inventories/7 %v\ngot: %v %v %v\n", want, f.method, f.uri, string(f.payload))
+		}
+	}
+}
+
+func TestDecrementInventory(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		by       int
+		requests int
+		err      bool
+	}{
+		{desc: "testing partial decrement", by: 2, requests: 2},
+		{desc: "testing full decrement", by: 3, requests: 2},
+		{desc: "testing insufficient quantity", by: 4, requests: 1, err: true},
+		{desc: "testing zero", by: 0, requests: 0, err: true},
+	}
+	for _, tc := range testCases {
+		s := &scriptedRequest{results: []scriptedResult{
+			{body: `{"meta":{"code":200},"data":{"inventory_id":7,"quantity":3}}`},
+			{body: `{"meta":{"code":200},"data":{"inventory_id":7}}`},
+		}}
+		bl := New("", "", "", "")
+		bl.request = s
+
+		err := bl.DecrementInventory(context.Background(), 7, tc.by)
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if len(s.methods) != tc.requests {
+			t.Errorf("%v, want: %v requests, got: %v\n", tc.desc, tc.requests, s.methods)
+		}
+	}
+}
+
+func TestUpsertInventory(t *testing.T) {
+	list := `{"meta":{"code":200},"data":[
+		{"inventory_id":7,"item":{"no":"3001","type":"PART"},"color_id":1,"quantity":3,"new_or_used":"N"},
+		{"inventory_id":8,"item":{"no":"3001","type":"PART"},"color_id":1,"quantity":3,"new_or_used":"U"}]}`
+	firstPage := `{"meta":{"code":200,"next_cursor":"c2"},"data":[
+		{"inventory_id":8,"item":{"no":"3001","type":"PART"},"color_id":1,"quantity":3,"new_or_used":"U"}]}`
+	secondPage := `{"meta":{"code":200},"data":[
+		{"inventory_id":7,"item":{"no":"3001","type":"PART"},"color_id":1,"quantity":3,"new_or_used":"N"}]}`
+	written := `{"meta":{"code":200},"data":{"inventory_id":7}}`
+
+	testCases := []struct {
+		desc    string
+		pages   []string
+		lot     InventoryCreate
+		opts    []UpsertOption
+		outcome string
+		methods string
+		listURI string
+		payload string
+	}{
+		{desc: "testing update", pages: []string{list},
+			lot:     InventoryCreate{Item: Item{No: "3001", Type: "PART"}, ColorID: 1, Quantity: 2, NewOrUsed: "N", UnitPrice: Money{Amount: 1000}},
+			outcome: UpsertUpdated, methods: "GET,PUT", listURI: "/inventories?item_type=PART", payload: `{"unit_price":"0.1000","quantity":"+2"}`},
+		{desc: "testing update without price", pages: []string{list},
+			lot:     InventoryCreate{Item: Item{No: "3001", Type: "PART"}, ColorID: 1, Quantity: 2, NewOrUsed: "N"},
+			outcome: UpsertUpdated, methods: "GET,PUT", listURI: "/inventories?item_type=PART", payload: `{"quantity":"+2"}`},
+		{desc: "testing update of a lot on the second page", pages: []string{firstPage, secondPage},
+			lot:     InventoryCreate{Item: Item{No: "3001", Type: "PART"}, ColorID: 1, Quantity: 2, NewOrUsed: "N"},
+			outcome: UpsertUpdated, methods: "GET,GET,PUT", listURI: "/inventories?item_type=PART", payload: `{"quantity":"+2"}`},
+		{desc: "testing create", pages: []string{list},
+			lot:     InventoryCreate{Item: Item{No: "3001", Type: "PART"}, ColorID: 5, Quantity: 2, NewOrUsed: "N"},
+			outcome: UpsertCreated, methods: "GET,POST", listURI: "/inventories?item_type=PART"},
+		{desc: "testing ambiguous custom match", pages: []string{list},
+			lot: InventoryCreate{Item: Item{No: "3001", Type: "PART"}, ColorID: 1, Quantity: 2, NewOrUsed: "N"},
+			opts: []UpsertOption{UpsertMatching(func(existing Inventory, lot InventoryCreate) bool {
+				return existing.Item.No == lot.Item.No
+			})}, outcome: "", methods: "GET", listURI: "/inventories"},
+	}
+	for _, tc := range testCases {
+		var results []scriptedResult
+		for _, page := range tc.pages {
+			results = append(results, scriptedResult{body: page})
+		}
+		s := &scriptedRequest{results: append(results, scriptedResult{body: written})}
+		bl := New("", "", "", "")
+		bl.request = s
+
+		outcome, err := bl.UpsertInventory(context.Background(), tc.lot, tc.opts...)
+		if (err != nil) != (tc.outcome == "") || outcome != tc.outcome {
+			t.Errorf("%v, want: %v, got: %v (%v)\n", tc.desc, tc.outcome, outcome, err)
+		}
+		if strings.Join(s.methods, ",") != tc.methods {
+			t.Errorf("%v, want requests: %v, got: %v\n", tc.desc, tc.methods, s.methods)
+		}
+		if s.uris[0] != tc.listURI {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.listURI, s.uris[0])
+		}
+		if outcome == UpsertUpdated && s.payloads[len(s.payloads)-1] != tc.payload {
+			t.Errorf("%v, want payload: %v, got: %v\n", tc.desc, tc.payload, s.payloads[len(s.payloads)-1])
+		}
+	}
+}
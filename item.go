@@ -0,0 +1,185 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Item is the short catalog item reference BrickLink embeds in inventories,
+// orders and subsets.
+type Item struct {
+	No         string `json:"no"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	CategoryID int    `json:"category_id"`
+}
+
+// CatalogItem is a BrickLink catalog item as returned by GetItemParsed.
+type CatalogItem struct {
+	No           string `json:"no"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	CategoryID   int    `json:"category_id"`
+	AlternateNo  string `json:"alternate_no"`
+	Weight       string `json:"weight"`
+	DimX         string `json:"dim_x"`
+	DimY         string `json:"dim_y"`
+	DimZ         string `json:"dim_z"`
+	YearReleased int    `json:"year_released"`
+	Description  string `json:"description"`
+
+	// IsObsolete is set for items which are no longer produced
+	IsObsolete bool `json:"is_obsolete"`
+
+	// ImageURL and ThumbnailURL link the item's main image, so GetItemImage
+	// is only needed for other colors. Protocol relative URLs are
+	// normalized to https.
+	ImageURL     string `json:"image_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// GetItemParsed querys for the specified item and returns it parsed.
+func (bl Bricklink) GetItemParsed(itemType, itemNumber string) (item CatalogItem, err error) {
+	return bl.catalogItem(bl.context(), itemType, itemNumber)
+}
+
+func (bl Bricklink) catalogItem(ctx context.Context, itemType, itemNumber string) (item CatalogItem, err error) {
+	// validate itemType
+	err = bl.validParam(itemType, itemTypes)
+	if err != nil {
+		return item, err
+	}
+
+	// validate itemNumber
+	if itemNumber == "" {
+		return item, errors.New("itemNumber is not specified")
+	}
+	itemNumber = bl.itemNumber(itemType, itemNumber)
+
+	err = bl.getParsed(ctx, "/items/"+itemType+"/"+itemNumber, &item)
+	if err != nil {
+		return item, err
+	}
+
+	item.ImageURL = normalizeURL(item.ImageURL)
+	item.ThumbnailURL = normalizeURL(item.ThumbnailURL)
+
+	return item, nil
+}
+
+// helper function to add the https scheme to protocol relative URLs, like
+// "//img.bricklink.com/..." as BrickLink often returns them
+func normalizeURL(u string) string {
+	if strings.HasPrefix(u, "//") {
+		return "https:" + u
+	}
+	return u
+}
+
+// GetItemsBatch fetches the catalog entries of the given items concurrently.
+// The result holds an entry for every item, in the same order; entries of
+// failed requests are left empty and their errors are returned as MultiError.
+// With WithFailFast the batch is aborted on the first error instead.
+func (bl Bricklink) GetItemsBatch(ctx context.Context, items []Item, opts ...BatchOption) ([]CatalogItem, error) {
+	o := bl.batchOptions(opts)
+
+	catalog := make([]CatalogItem, len(items))
+	errs, first := runBatch(ctx, len(items), o, func(ctx context.Context, i int) (err error) {
+		catalog[i], err = bl.catalogItem(ctx, items[i].Type, items[i].No)
+		return err
+	})
+
+	if o.failFast && first != nil {
+		return catalog, first
+	}
+
+	var merr MultiError
+	for i, err := range errs {
+		if err != nil {
+			merr = append(merr, fmt.Errorf("item %v %v: %w", items[i].Type, items[i].No, err))
+		}
+	}
+
+	return catalog, merr.errOrNil()
+}
+
+// ItemMatch is a catalog item found by GetItemAnyType.
+type ItemMatch struct {
+	Type string
+	Item CatalogItem
+}
+
+// GetItemAnyType looks up the item number in the given item types, or all
+// item types if none are given, concurrently. It returns the matches in the
+// order of the types, so ambiguous numbers can be presented to the user.
+// Types the number doesn't exist in are skipped; if it exists in none, an
+// error matching ErrNotFound is returned. Other failures are returned as
+// MultiError along with the matches found.
+func (bl Bricklink) GetItemAnyType(itemNumber string, types ...string) ([]ItemMatch, error) {
+	if len(types) == 0 {
+		types = itemTypes
+	}
+	for _, t := range types {
+		err := bl.validParam(t, itemTypes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	catalog := make([]CatalogItem, len(types))
+	errs, _ := runBatch(bl.context(), len(types), bl.batchOptions(nil), func(ctx context.Context, i int) (err error) {
+		catalog[i], err = bl.catalogItem(ctx, types[i], itemNumber)
+		return err
+	})
+
+	var matches []ItemMatch
+	var merr MultiError
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			matches = append(matches, ItemMatch{Type: types[i], Item: catalog[i]})
+		case !errors.Is(err, ErrNotFound):
+			merr = append(merr, fmt.Errorf("item %v %v: %w", types[i], itemNumber, err))
+		}
+	}
+
+	if len(matches) == 0 && len(merr) == 0 {
+		return nil, fmt.Errorf("item %v: %w", itemNumber, ErrNotFound)
+	}
+
+	return matches, merr.errOrNil()
+}
+
+// ResolveCategory returns the category of the item. The category list is
+// served from the cache if caching is enabled, so resolving many items only
+// fetches it once; see ResolveCategoryFrom to use loaded ReferenceData.
+func (item CatalogItem) ResolveCategory(bl *Bricklink) (Category, error) {
+	categories, err := bl.GetCategoryListParsed()
+	if err != nil {
+		return Category{}, err
+	}
+
+	for _, c := range categories {
+		if c.CategoryID == item.CategoryID {
+			return c, nil
+		}
+	}
+
+	return Category{}, fmt.Errorf("category %d: %w", item.CategoryID, ErrNotFound)
+}
+
+// ResolveCategoryFrom is like ResolveCategory but looks the category up in
+// the reference data, without any request.
+func (item CatalogItem) ResolveCategoryFrom(ref *ReferenceData) (Category, error) {
+	c, ok := ref.Category(item.CategoryID)
+	if !ok {
+		return Category{}, fmt.Errorf("category %d: %w", item.CategoryID, ErrNotFound)
+	}
+	return c, nil
+}
@@ -0,0 +1,99 @@
+package bricklinkapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetItemParsedImageURLs(t *testing.T) {
+	testCases := []struct {
+		desc string
+		url  string
+		expS string
+	}{
+		{desc: "testing protocol relative url", url: "//img.bricklink.com/PL/3001.png", expS: "https://img.bricklink.com/PL/3001.png"},
+		{desc: "testing absolute url", url: "http://img.bricklink.com/PL/3001.png", expS: "http://img.bricklink.com/PL/3001.png"},
+		{desc: "testing missing url", url: "", expS: ""},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "")
+		bl.request = &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"no":"3001","type":"PART",` +
+			`"image_url":"` + tc.url + `","thumbnail_url":"` + tc.url + `"}}`)}
+
+		item, err := bl.GetItemParsed("PART", "3001")
+		if err != nil || item.ImageURL != tc.expS || item.ThumbnailURL != tc.expS {
+			t.Errorf("%v, want: %v, got: %v, %v (%v)\n", tc.desc, tc.expS, item.ImageURL, item.ThumbnailURL, err)
+		}
+	}
+}
+
+func TestGetItemAnyType(t *testing.T) {
+	notFound := `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/items/PART/": `{"meta":{"code":200},"data":{"no":"3001","type":"PART"}}`,
+		"/items/GEAR/": `{"meta":{"code":200},"data":{"no":"3001","type":"GEAR"}}`,
+		"/items/SET/":  `{"meta":{"code":500,"message":"SERVER_ERROR"}}`,
+		"/items/BOOK/": notFound,
+		"/items/MINIF": notFound,
+	}
+
+	matches, err := bl.GetItemAnyType("3001", "SET", "GEAR", "BOOK", "PART")
+	if merr, ok := err.(MultiError); !ok || len(merr) != 1 {
+		t.Errorf("\nwant: one error for SET, got: %v\n", err)
+	}
+	if len(matches) != 2 || matches[0].Type != "GEAR" || matches[1].Item.Type != "PART" {
+		t.Errorf("\nunexpected matches: %+v\n", matches)
+	}
+
+	_, err = bl.GetItemAnyType("3001", "BOOK", "MINIFIG")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("\nwant: ErrNotFound, got: %v\n", err)
+	}
+
+	_, err = bl.GetItemAnyType("3001", "BRICK")
+	if err == nil {
+		t.Errorf("\nexpected error for invalid type\n")
+	}
+}
+
+func TestResolveCategory(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/categories": `{"meta":{"code":200},"data":[{"category_id":5,"category_name":"Brick"},{"category_id":26,"category_name":"Plate"}]}`,
+		"/colors":     `{"meta":{"code":200},"data":[]}`,
+	}
+
+	ref, err := bl.LoadReferenceData(bl.context())
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+
+	testCases := []struct {
+		desc       string
+		categoryID int
+		expS       string
+	}{
+		{desc: "testing first category", categoryID: 5, expS: "Brick"},
+		{desc: "testing second category", categoryID: 26, expS: "Plate"},
+		{desc: "testing unknown category", categoryID: 99, expS: ""},
+	}
+	for _, tc := range testCases {
+		item := CatalogItem{No: "3001", Type: "PART", CategoryID: tc.categoryID}
+		for _, resolve := range []func() (Category, error){
+			func() (Category, error) { return item.ResolveCategory(bl) },
+			func() (Category, error) { return item.ResolveCategoryFrom(ref) },
+		} {
+			c, err := resolve()
+			if tc.expS == "" {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("%v, want: ErrNotFound, got: %v\n", tc.desc, err)
+				}
+				continue
+			}
+			if err != nil || c.CategoryName != tc.expS {
+				t.Errorf("%v, want: %v, got: %+v, %v\n", tc.desc, tc.expS, c, err)
+			}
+		}
+	}
+}
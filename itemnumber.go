@@ -0,0 +1,90 @@
+package bricklinkapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidateItemNumber checks itemNumber against the format of the item type.
+// The rules are lenient on purpose, as BrickLink numbers vary a lot:
+//
+//   - numbers must not be empty and may only contain letters, digits and
+//     the signs "-", ".", "_"
+//   - SET numbers must carry a variant suffix, e.g. "6020-1" instead of
+//     "6020"; see WithSetVariantSuffix to append it automatically
+//
+// A nil error doesn't mean the item exists.
+func ValidateItemNumber(itemType, itemNumber string) error {
+	err := validateParam(itemType, itemTypes)
+	if err != nil {
+		return err
+	}
+
+	if itemNumber == "" {
+		return errors.New("itemNumber is not specified")
+	}
+
+	for _, c := range itemNumber {
+		if !('A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_') {
+			return fmt.Errorf("itemNumber \"%v\" contains invalid character %q", itemNumber, c)
+		}
+	}
+
+	if strings.EqualFold(itemType, "SET") && !hasVariant(itemNumber) {
+		return fmt.Errorf("set number \"%v\" lacks a variant suffix, e.g. \"%v-1\"", itemNumber, itemNumber)
+	}
+
+	return nil
+}
+
+// itemNumber returns the item number to request. With WithSetVariantSuffix
+// set, "-1" is appended to set numbers without variant.
+func (bl Bricklink) itemNumber(itemType, itemNumber string) string {
+	if bl.setVariantSuffix && strings.EqualFold(itemType, "SET") {
+		return NormalizeSetNumber(itemNumber)
+	}
+	return itemNumber
+}
+
+// NormalizeSetNumber returns the set number in BrickLink's form, appending
+// the default variant suffix "-1" if the number has none, e.g. "75192"
+// becomes "75192-1". Surrounding whitespace is removed.
+func NormalizeSetNumber(setNumber string) string {
+	setNumber = strings.TrimSpace(setNumber)
+	if setNumber == "" || hasVariant(setNumber) {
+		return setNumber
+	}
+	return setNumber + "-1"
+}
+
+// StripSetVariant removes the default variant suffix "-1" from the set
+// number, e.g. "75192-1" becomes "75192", for databases leaving it out.
+// Other variants, e.g. "75192-2", are kept as they identify a different set.
+func StripSetVariant(setNumber string) string {
+	setNumber = strings.TrimSpace(setNumber)
+	return strings.TrimSuffix(setNumber, "-1")
+}
+
+// SameSetNumber reports whether both numbers denote the same set, with or
+// without the default variant suffix and ignoring case, e.g. "75192" and
+// "75192-1".
+func SameSetNumber(a, b string) bool {
+	return strings.EqualFold(NormalizeSetNumber(a), NormalizeSetNumber(b))
+}
+
+// helper function to check if a number ends with a numeric variant suffix
+func hasVariant(itemNumber string) bool {
+	i := strings.LastIndexByte(itemNumber, '-')
+	if i <= 0 || i == len(itemNumber)-1 {
+		return false
+	}
+
+	for _, c := range itemNumber[i+1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
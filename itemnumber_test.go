@@ -0,0 +1,70 @@
+package bricklinkapi
+
+import (
+	"testing"
+)
+
+func TestValidateItemNumber(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		itemType   string
+		itemNumber string
+		valid      bool
+	}{
+		{desc: "testing part", itemType: "PART", itemNumber: "3001", valid: true},
+		{desc: "testing printed part", itemType: "part", itemNumber: "3626bpb0001", valid: true},
+		{desc: "testing minifig", itemType: "MINIFIG", itemNumber: "sw0001a", valid: true},
+		{desc: "testing set", itemType: "SET", itemNumber: "75192-1", valid: true},
+		{desc: "testing set without variant", itemType: "SET", itemNumber: "75192", valid: false},
+		{desc: "testing set with trailing dash", itemType: "SET", itemNumber: "75192-", valid: false},
+		{desc: "testing empty", itemType: "PART", itemNumber: "", valid: false},
+		{desc: "testing invalid character", itemType: "PART", itemNumber: "30 01", valid: false},
+		{desc: "testing invalid type", itemType: "FOO", itemNumber: "3001", valid: false},
+	}
+	for _, tc := range testCases {
+		err := ValidateItemNumber(tc.itemType, tc.itemNumber)
+		if (err == nil) != tc.valid {
+			t.Errorf("%v \"%v\", want valid: %v, got: %v\n", tc.desc, tc.itemNumber, tc.valid, err)
+		}
+	}
+}
+
+func TestSetVariantSuffix(t *testing.T) {
+	bl := New("", "", "", "", WithSetVariantSuffix())
+	if n := bl.itemNumber("SET", "75192"); n != "75192-1" {
+		t.Errorf("\nwant: %v, got: %v\n", "75192-1", n)
+	}
+	if n := bl.itemNumber("SET", "75192-2"); n != "75192-2" {
+		t.Errorf("\nwant: %v, got: %v\n", "75192-2", n)
+	}
+	if n := bl.itemNumber("PART", "3001"); n != "3001" {
+		t.Errorf("\nwant: %v, got: %v\n", "3001", n)
+	}
+}
+
+func TestSetNumberConversion(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		number     string
+		normalized string
+		stripped   string
+	}{
+		{desc: "testing without variant", number: "75192", normalized: "75192-1", stripped: "75192"},
+		{desc: "testing first variant", number: "75192-1", normalized: "75192-1", stripped: "75192"},
+		{desc: "testing other variant", number: " 75192-2 ", normalized: "75192-2", stripped: "75192-2"},
+		{desc: "testing letters", number: "sw0001a", normalized: "sw0001a-1", stripped: "sw0001a"},
+		{desc: "testing empty", number: "", normalized: "", stripped: ""},
+	}
+	for _, tc := range testCases {
+		if result := NormalizeSetNumber(tc.number); result != tc.normalized {
+			t.Errorf("%v, normalize want: %v, got: %v\n", tc.desc, tc.normalized, result)
+		}
+		if result := StripSetVariant(tc.number); result != tc.stripped {
+			t.Errorf("%v, strip want: %v, got: %v\n", tc.desc, tc.stripped, result)
+		}
+	}
+
+	if !SameSetNumber("75192", "75192-1") || SameSetNumber("75192", "75192-2") {
+		t.Errorf("\nunexpected set number comparison\n")
+	}
+}
@@ -0,0 +1,75 @@
+package bricklinkapi
+
+import (
+	"context"
+	"errors"
+)
+
+// Member identifies the authenticated seller.
+type Member struct {
+	UserName  string
+	StoreName string
+}
+
+// GetSelf returns the user and store name the credentials belong to.
+//
+// The store API has no endpoint for the authenticated member's profile, so
+// the information is taken from the most recent order received by the store.
+// For accounts which never received an order the user name is taken from the
+// most recent order they placed, leaving StoreName empty. Only unfiled orders
+// are considered; without any an error is returned. Use VerifyCredentials to
+// only check the credentials.
+func (bl Bricklink) GetSelf(ctx context.Context) (member Member, err error) {
+	received, err := bl.latestOrder(ctx, "in")
+	if err != nil {
+		return member, err
+	}
+	if received != nil {
+		member.UserName = received.SellerName
+		member.StoreName = received.StoreName
+		return member, nil
+	}
+
+	placed, err := bl.latestOrder(ctx, "out")
+	if err != nil {
+		return member, err
+	}
+	if placed != nil {
+		member.UserName = placed.BuyerName
+		return member, nil
+	}
+
+	return member, errors.New("no orders to take the member info from")
+}
+
+// latestOrder returns the most recently placed order of the direction, or nil
+// if there is none. The list isn't sorted reliably, so the dates are compared.
+func (bl Bricklink) latestOrder(ctx context.Context, direction string) (*Order, error) {
+	var orders []Order
+	err := bl.getParsed(ctx, buildURI("/orders", map[string]string{"direction": direction}), &orders)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *Order
+	for i, o := range orders {
+		if latest == nil || o.DateOrdered.After(latest.DateOrdered) {
+			latest = &orders[i]
+		}
+	}
+	return latest, nil
+}
+
+// VerifyCredentials issues a cheap authenticated request and returns an error
+// if BrickLink rejects the credentials, matching ErrUnauthorized for invalid
+// ones. The request is a catalog lookup of a single color, which every
+// account may read, so it doesn't tell whether the credentials may access
+// the store's orders or inventory.
+func (bl Bricklink) VerifyCredentials(ctx context.Context) error {
+	body, err := bl.send(ctx, "GET", "/colors/1", nil)
+	if err != nil {
+		return err
+	}
+
+	return bl.decode(body, nil)
+}
@@ -0,0 +1,61 @@
+package bricklinkapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGetSelf(t *testing.T) {
+	received := `{"meta":{"code":200},"data":[
+		{"order_id":1,"date_ordered":"2020-01-01T10:00:00.000Z","seller_name":"brickseller","store_name":"Old Name"},
+		{"order_id":2,"date_ordered":"2020-03-01T10:00:00.000Z","seller_name":"brickseller","store_name":"Brick Store"},
+		{"order_id":3,"date_ordered":"2020-02-01T10:00:00.000Z","seller_name":"brickseller","store_name":"Other Name"}]}`
+	placed := `{"meta":{"code":200},"data":[{"order_id":4,"date_ordered":"2020-01-01T10:00:00.000Z","buyer_name":"brickbuyer"}]}`
+	empty := `{"meta":{"code":200},"data":[]}`
+
+	testCases := []struct {
+		desc     string
+		received string
+		placed   string
+		exp      Member
+		err      bool
+	}{
+		{desc: "testing received orders", received: received, placed: placed, exp: Member{UserName: "brickseller", StoreName: "Brick Store"}},
+		{desc: "testing placed orders only", received: empty, placed: placed, exp: Member{UserName: "brickbuyer"}},
+		{desc: "testing no orders", received: empty, placed: empty, err: true},
+		{desc: "testing failed request", received: `{"meta":{"code":401,"message":"BAD_OAUTH_REQUEST"}}`, placed: placed, err: true},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "")
+		bl.request = routeRequest{
+			"/orders?direction=in":  tc.received,
+			"/orders?direction=out": tc.placed,
+		}
+
+		member, err := bl.GetSelf(context.Background())
+		if (err != nil) != tc.err || member != tc.exp {
+			t.Errorf("%v, want: %+v, error %v, got: %+v, %v\n", tc.desc, tc.exp, tc.err, member, err)
+		}
+	}
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	bl := New("", "", "", "")
+	f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"color_id":1}}`)}
+	bl.request = f
+
+	err := bl.VerifyCredentials(context.Background())
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	if f.method != "GET" || f.uri != "/colors/1" {
+		t.Errorf("\nunexpected request: %v %v\n", f.method, f.uri)
+	}
+
+	bl.request = &fakeRequest{body: []byte(`{"meta":{"code":401,"message":"BAD_OAUTH_REQUEST"}}`)}
+	err = bl.VerifyCredentials(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("\nwant: ErrUnauthorized, got: %v\n", err)
+	}
+}
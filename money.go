@@ -0,0 +1,185 @@
+package bricklinkapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// moneyScale is the number of units per whole currency unit. BrickLink
+// reports prices with four decimal places.
+const moneyScale = 10000
+
+// Money is a fixed point monetary amount as used by BrickLink. Amount is
+// stored in ten-thousandths of the currency unit, so "1.2340" is 12340.
+// Currency is the ISO 4217 code, it is empty where BrickLink does not send
+// one (e.g. inventory prices, which are in the store currency).
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// ParseMoney parses a decimal string like "1.2340" into Money. Digits beyond
+// the fourth decimal place are rounded half away from zero.
+func ParseMoney(s, currency string) (m Money, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return m, errors.New("amount is empty")
+	}
+
+	neg := false
+	digits := s
+	if digits[0] == '-' || digits[0] == '+' {
+		neg = digits[0] == '-'
+		digits = digits[1:]
+	}
+
+	whole, frac := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		whole, frac = digits[:i], digits[i+1:]
+	}
+	if whole+frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return m, fmt.Errorf("amount \"%v\" is not valid", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	// pad the fraction, its fifth digit decides the rounding
+	frac += "00000"
+	round := frac[4] >= '5'
+	frac = frac[:4]
+
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || w > (math.MaxInt64-moneyScale)/moneyScale {
+		return m, fmt.Errorf("amount \"%v\" is not valid", s)
+	}
+	f, _ := strconv.ParseInt(frac, 10, 64)
+
+	m.Amount = w*moneyScale + f
+	if round {
+		m.Amount++
+	}
+	if neg {
+		m.Amount = -m.Amount
+	}
+	m.Currency = currency
+
+	return m, nil
+}
+
+// helper function to check if s consists of ASCII digits only
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Float64 returns the amount as a float64 in whole currency units.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / moneyScale
+}
+
+// String returns the amount with four decimal places, the format BrickLink
+// uses, e.g. "1.2340".
+func (m Money) String() string {
+	a := m.Amount
+	sign := ""
+	if a < 0 {
+		sign = "-"
+		a = -a
+	}
+	return fmt.Sprintf("%v%d.%04d", sign, a/moneyScale, a%moneyScale)
+}
+
+// MarshalJSON encodes the amount as quoted decimal string.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes an amount sent either as quoted decimal string or
+// as plain number. The currency is left untouched.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), "\"")
+	if s == "" || s == "null" {
+		m.Amount = 0
+		return nil
+	}
+
+	parsed, err := ParseMoney(s, m.Currency)
+	if err != nil {
+		return err
+	}
+	m.Amount = parsed.Amount
+
+	return nil
+}
+
+// currencyFormat describes how amounts of a currency are displayed
+type currencyFormat struct {
+	symbol   string
+	decimals int
+}
+
+// currencyFormats holds the display formats of common currencies
+var currencyFormats = map[string]currencyFormat{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"CAD": {"CA$", 2},
+	"AUD": {"A$", 2},
+	"NZD": {"NZ$", 2},
+	"CHF": {"CHF ", 2},
+	"CNY": {"CN¥", 2},
+	"KRW": {"₩", 0},
+	"INR": {"₹", 2},
+	"BRL": {"R$", 2},
+	"MXN": {"MX$", 2},
+	"PLN": {"zł", 2},
+	"SEK": {"kr", 2},
+	"DKK": {"kr", 2},
+	"NOK": {"kr", 2},
+	"CZK": {"Kč", 2},
+	"HUF": {"Ft", 0},
+}
+
+// Format renders the amount for display in its currency, rounded to the
+// decimals of the currency, e.g. "£1.23" or "¥123". Unknown currencies are
+// rendered as "CODE 1.23", amounts without currency as "1.23".
+func (m Money) Format() string {
+	f, ok := currencyFormats[strings.ToUpper(m.Currency)]
+	if !ok {
+		f = currencyFormat{decimals: 2}
+		if m.Currency != "" {
+			f.symbol = strings.ToUpper(m.Currency) + " "
+		}
+	}
+
+	a := m.Amount
+	sign := ""
+	if a < 0 {
+		sign = "-"
+		a = -a
+	}
+
+	// round half away from zero to the currency decimals
+	div := int64(1)
+	for i := f.decimals; i < 4; i++ {
+		div *= 10
+	}
+	a = (a + div/2) / div
+
+	if f.decimals == 0 {
+		return fmt.Sprintf("%v%v%d", sign, f.symbol, a)
+	}
+
+	unit := moneyScale / div
+	return fmt.Sprintf("%v%v%d.%0*d", sign, f.symbol, a/unit, f.decimals, a%unit)
+}
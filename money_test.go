@@ -0,0 +1,81 @@
+package bricklinkapi
+
+import (
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    string
+		exp  int64
+		err  bool
+	}{
+		{desc: "testing four decimals", s: "1.2340", exp: 12340},
+		{desc: "testing short fraction", s: "1.5", exp: 15000},
+		{desc: "testing no fraction", s: "12", exp: 120000},
+		{desc: "testing rounding down", s: "0.123449", exp: 1234},
+		{desc: "testing rounding up", s: "0.12345", exp: 1235},
+		{desc: "testing rounding negative", s: "-0.99995", exp: -10000},
+		{desc: "testing negative", s: "-0.0500", exp: -500},
+		{desc: "testing plus sign", s: "+2", exp: 20000},
+		{desc: "testing no whole", s: ".5", exp: 5000},
+		{desc: "testing empty", s: "", err: true},
+		{desc: "testing garbage", s: "abc", err: true},
+		{desc: "testing double sign", s: "--1", err: true},
+		{desc: "testing signed fraction", s: "1.-5", err: true},
+		{desc: "testing sign only", s: "-", err: true},
+		{desc: "testing dot only", s: ".", err: true},
+		{desc: "testing second dot", s: "1.2.3", err: true},
+		{desc: "testing overflow", s: "99999999999999999999", err: true},
+	}
+	for _, tc := range testCases {
+		m, err := ParseMoney(tc.s, "")
+		if (err != nil) != tc.err {
+			t.Errorf("%v \"%v\", unexpected error: %v\n", tc.desc, tc.s, err)
+			continue
+		}
+		if m.Amount != tc.exp {
+			t.Errorf("%v \"%v\", want: %v, got: %v\n", tc.desc, tc.s, tc.exp, m.Amount)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	testCases := []struct {
+		desc string
+		m    Money
+		expS string
+	}{
+		{desc: "testing zero", m: Money{}, expS: "0.0000"},
+		{desc: "testing amount", m: Money{Amount: 12340}, expS: "1.2340"},
+		{desc: "testing negative", m: Money{Amount: -500}, expS: "-0.0500"},
+	}
+	for _, tc := range testCases {
+		result := tc.m.String()
+		if result != tc.expS {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expS, result)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	testCases := []struct {
+		desc string
+		m    Money
+		expS string
+	}{
+		{desc: "testing pound", m: Money{Amount: 12340, Currency: "GBP"}, expS: "£1.23"},
+		{desc: "testing rounding", m: Money{Amount: 12350, Currency: "USD"}, expS: "$1.24"},
+		{desc: "testing yen", m: Money{Amount: 1230000, Currency: "JPY"}, expS: "¥123"},
+		{desc: "testing negative", m: Money{Amount: -12340, Currency: "eur"}, expS: "-€1.23"},
+		{desc: "testing unknown currency", m: Money{Amount: 12340, Currency: "XYZ"}, expS: "XYZ 1.23"},
+		{desc: "testing no currency", m: Money{Amount: 12340}, expS: "1.23"},
+	}
+	for _, tc := range testCases {
+		result := tc.m.Format()
+		if result != tc.expS {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expS, result)
+		}
+	}
+}
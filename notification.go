@@ -0,0 +1,81 @@
+package bricklinkapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType is the type of the event a notification is about.
+type EventType string
+
+// Event types of notifications as used by BrickLink
+const (
+	EventOrder    EventType = "Order"
+	EventMessage  EventType = "Message"
+	EventFeedback EventType = "Feedback"
+)
+
+// Notification is an unread push notification: ResourceID is the ID of the
+// order, message or feedback the event is about.
+type Notification struct {
+	EventType  EventType `json:"event_type"`
+	ResourceID int       `json:"resource_id"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// UnmarshalJSON decodes a notification, parsing its timestamp leniently.
+func (n *Notification) UnmarshalJSON(b []byte) error {
+	type alias Notification
+	aux := struct {
+		*alias
+		Timestamp timestamp `json:"timestamp"`
+	}{alias: (*alias)(n)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+	n.Timestamp = time.Time(aux.Timestamp)
+
+	return nil
+}
+
+// GetNotifications issues a GET request to the Bricklink API and querys for
+// the unread push notifications.
+func (bl Bricklink) GetNotifications() (response string, err error) {
+	body, err := bl.GetNotificationsBytes()
+	return string(body), err
+}
+
+// GetNotificationsBytes is like GetNotifications but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetNotificationsBytes() (response []byte, err error) {
+	body, err := bl.send(bl.context(), "GET", "/notifications", nil)
+	if err != nil {
+		return response, err
+	}
+
+	return body, nil
+}
+
+// GetNotificationsParsed querys for the unread push notifications and
+// returns them parsed. Notifications are never served from the cache.
+func (bl Bricklink) GetNotificationsParsed() (notifications []Notification, err error) {
+	body, err := bl.send(bl.context(), "GET", "/notifications", nil)
+	if err != nil {
+		return notifications, err
+	}
+
+	err = bl.decode(body, &notifications)
+	return notifications, err
+}
+
+// SummarizeNotifications counts the notifications by event type, e.g. to
+// show the backlog after polling.
+func SummarizeNotifications(notifications []Notification) map[EventType]int {
+	counts := make(map[EventType]int)
+	for _, n := range notifications {
+		counts[n.EventType]++
+	}
+	return counts
+}
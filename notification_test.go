@@ -0,0 +1,27 @@
+package bricklinkapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetNotificationsParsed(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[
+		{"event_type":"Order","resource_id":1,"timestamp":"2013-12-15T11:57:55.000Z"},
+		{"event_type":"Message","resource_id":2,"timestamp":"2013-12-15T12:00:00.000Z"},
+		{"event_type":"Order","resource_id":3,"timestamp":null}]}`)}
+
+	notifications, err := bl.GetNotificationsParsed()
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if len(notifications) != 3 || !notifications[0].Timestamp.Equal(time.Date(2013, 12, 15, 11, 57, 55, 0, time.UTC)) {
+		t.Errorf("\nunexpected notifications: %+v\n", notifications)
+	}
+
+	counts := SummarizeNotifications(notifications)
+	if len(counts) != 2 || counts[EventOrder] != 2 || counts[EventMessage] != 1 || counts[EventFeedback] != 0 {
+		t.Errorf("\nunexpected counts: %v\n", counts)
+	}
+}
@@ -0,0 +1,295 @@
+package bricklinkapi
+
+import (
+	"context"
+	"time"
+)
+
+// Option configures a Bricklink handler. Options are passed to New.
+type Option func(*Bricklink)
+
+// Logger is the interface used for logging, it is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLanguage sets the Accept-Language header sent with every request, so
+// BrickLink can return localized strings (e.g. color and category names)
+// where it supports them. When unset no header is sent and the server
+// default is used.
+func WithLanguage(lang string) Option {
+	return func(bl *Bricklink) {
+		bl.language = lang
+	}
+}
+
+// WithBaseURL sends the requests to host instead of https://api.bricklink.com,
+// e.g. an internal gateway proxying BrickLink. The API path /api/store/v1 is
+// appended to it, see WithBasePath to mount it under a prefix. The OAuth
+// signature covers the resulting URL, so the gateway must forward the
+// requests unchanged.
+func WithBaseURL(host string) Option {
+	return func(bl *Bricklink) {
+		bl.baseURL = host
+	}
+}
+
+// WithBasePath prepends the path prefix to the API path /api/store/v1, for
+// gateways mounting the API under a path, e.g. "/bricklink" results in
+// https://api.bricklink.com/bricklink/api/store/v1 or the host set with
+// WithBaseURL. Leading and trailing slashes are optional.
+func WithBasePath(prefix string) Option {
+	return func(bl *Bricklink) {
+		bl.basePath = prefix
+	}
+}
+
+// WithBaseContext sets a context every request is derived from. Cancelling
+// it aborts all in-flight requests, which gives a clean shutdown signal.
+//
+// Precedence: methods taking a context parameter use that context and ignore
+// the base context. All other methods use the base context, or
+// context.Background if none is set. In both cases every single request is
+// bound by the timeout set with WithReadTimeout or WithWriteTimeout (30
+// seconds by default) on top; a deadline of the context still applies, the
+// earlier of both ends the request.
+func WithBaseContext(ctx context.Context) Option {
+	return func(bl *Bricklink) {
+		bl.baseCtx = ctx
+	}
+}
+
+// WithCache enables caching of the responses of the parsed GET methods for
+// the duration of ttl. This is most useful for stable reference data like
+// colors and categories, see Warmup. A ttl of 0 or below keeps the responses
+// until they are evicted by WithLRUCache; without it caching stays disabled,
+// so the cache never grows without bound.
+func WithCache(ttl time.Duration) Option {
+	return func(bl *Bricklink) {
+		if bl.cache == nil {
+			bl.cache = newCache(ttl, 0)
+			return
+		}
+		bl.cache.ttl = ttl
+	}
+}
+
+// WithPriceGuideMaxAge refetches cached price guides once they are older
+// than maxAge, while other cached responses are kept for the duration set
+// with WithCache. It has no effect without caching enabled.
+func WithPriceGuideMaxAge(maxAge time.Duration) Option {
+	return func(bl *Bricklink) {
+		bl.priceGuideMaxAge = maxAge
+	}
+}
+
+// WithLRUCache enables caching of the responses of the parsed GET methods,
+// keeping up to maxEntries responses and evicting the least recently used
+// ones beyond, e.g. for repeated lookups of popular items. Combined with
+// WithCache, entries are evicted by size and expire after the ttl. A
+// maxEntries of 0 or below disables caching, also if WithCache is set.
+func WithLRUCache(maxEntries int) Option {
+	return func(bl *Bricklink) {
+		if maxEntries <= 0 {
+			maxEntries = -1
+		}
+		if bl.cache == nil {
+			bl.cache = newCache(0, maxEntries)
+			return
+		}
+		bl.cache.maxEntries = maxEntries
+	}
+}
+
+// WithJSONUnmarshal replaces encoding/json for decoding responses of the
+// parsed methods, e.g. with a faster drop-in library. The function must
+// behave like json.Unmarshal, including honouring the json struct tags.
+func WithJSONUnmarshal(fn UnmarshalFunc) Option {
+	return func(bl *Bricklink) {
+		bl.unmarshal = fn
+	}
+}
+
+// WithRetry retries GET requests failing with a network error up to
+// maxRetries times. The wait between attempts starts at backoff and doubles
+// with every retry. Writes are not retried, see WithVerifiedWrites.
+//
+// With retries enabled, empty responses of unknown length count as network
+// errors; without, they are returned as is. The parsed methods additionally
+// give a cut off response one extra attempt.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(bl *Bricklink) {
+		bl.retries = maxRetries
+		bl.backoff = backoff
+	}
+}
+
+// WithVerifiedWrites extends retries to order and payment status updates.
+// After an ambiguous failure the order is fetched to verify whether the write
+// was applied before it is retried, so the update is never sent twice once
+// applied. If the verification fails the write error is returned instead of
+// retrying. Each verification costs one extra GET request. It has no effect
+// without WithRetry.
+func WithVerifiedWrites() Option {
+	return func(bl *Bricklink) {
+		bl.verifyWrites = true
+	}
+}
+
+// WithTextNormalization normalizes the free text sent to BrickLink, i.e. the
+// description and remarks of lots and feedback comments, instead of rejecting
+// characters BrickLink mangles: typographic quotes, dashes, ellipses and
+// spaces are replaced with their ASCII counterparts, carriage returns become
+// newlines, other control characters than tab and newline are dropped, as is
+// invalid UTF-8. By default the text is sent exactly as given.
+func WithTextNormalization() Option {
+	return func(bl *Bricklink) {
+		bl.normalizeText = true
+	}
+}
+
+// WithRequestCoalescing makes concurrent identical requests of the parsed
+// GET methods share a single request and its response, e.g. for a web server
+// looking up the same item for many clients at once. Requests are identical
+// if their URIs are, the OAuth nonce and signature aside. The request is
+// issued with the context of the first caller; the others stop waiting when
+// their own context is done. The raw methods returning the response as string
+// or []byte, e.g. GetItem and GetItemBytes, and the paginated list methods
+// are not coalesced and always send their own requests.
+func WithRequestCoalescing() Option {
+	return func(bl *Bricklink) {
+		bl.flight = newFlightGroup()
+	}
+}
+
+// WithStrictMeta makes responses without meta block or meta code fail with
+// ErrMissingMeta, e.g. when a proxy or middlebox altered them. As the outcome
+// of such a request is unknown, this is safer than the default, which takes
+// them as success and decodes their data.
+func WithStrictMeta() Option {
+	return func(bl *Bricklink) {
+		bl.strictMeta = true
+	}
+}
+
+// WithSkipValidation skips the client side checks of params and lot fields,
+// e.g. of item types, statuses, currency codes, completeness and bulk, for
+// callers which validated their input already. Invalid values are then sent
+// as given and only rejected by BrickLink, if at all, at the cost of a
+// request; lots and feedback may be sent with text BrickLink mangles. Empty item
+// numbers are still rejected and requests are always signed.
+func WithSkipValidation(skip bool) Option {
+	return func(bl *Bricklink) {
+		bl.skipValidation = skip
+	}
+}
+
+// WithRateLimit limits the requests of the handler to requestsPerSecond,
+// allowing bursts of up to burst requests. The limit is shared by all
+// methods, including the concurrent requests of the batch helpers. A
+// requestsPerSecond of 0 or below means no limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(bl *Bricklink) {
+		bl.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithLogger sets the logger the handler writes its diagnostics to. Nothing
+// is logged by default.
+func WithLogger(logger Logger) Option {
+	return func(bl *Bricklink) {
+		bl.logger = logger
+	}
+}
+
+// WithSlowRequestThreshold logs every request taking longer than d, with its
+// endpoint and duration. It has no effect without WithLogger.
+func WithSlowRequestThreshold(d time.Duration) Option {
+	return func(bl *Bricklink) {
+		bl.slowThreshold = d
+	}
+}
+
+// WithSignatureLogging logs the OAuth signature base string of every request,
+// with the consumer key and token redacted, to diagnose proxies or gateways
+// which alter requests and break their signature. Secrets are never part of
+// the base string. It has no effect without WithLogger.
+func WithSignatureLogging() Option {
+	return func(bl *Bricklink) {
+		bl.logSignatures = true
+	}
+}
+
+// WithSetVariantSuffix appends the variant suffix "-1" to set numbers which
+// lack one (e.g. "6020" becomes "6020-1"), catching the common mistake of
+// leaving it out. Numbers with a variant are left untouched.
+func WithSetVariantSuffix() Option {
+	return func(bl *Bricklink) {
+		bl.setVariantSuffix = true
+	}
+}
+
+// WithMaxConcurrency limits the number of concurrent requests of all batch
+// helpers (e.g. GetItemsBatch, EnrichOrderItems) to n in total, so several
+// batches running at once don't add up to a burst. It composes with
+// WithRateLimit: a request needs a concurrency slot and a rate limit token.
+func WithMaxConcurrency(n int) Option {
+	return func(bl *Bricklink) {
+		if n > 0 {
+			bl.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithReadTimeout sets the timeout of a single GET request, overriding the
+// default of 30 seconds. Each retry gets the full timeout again. See
+// WithBaseContext for how it combines with context deadlines.
+func WithReadTimeout(d time.Duration) Option {
+	return func(bl *Bricklink) {
+		bl.readTimeout = d
+	}
+}
+
+// WithWriteTimeout sets the timeout of a single write request (POST, PUT,
+// DELETE), overriding the default of 30 seconds. See WithBaseContext for how
+// it combines with context deadlines.
+func WithWriteTimeout(d time.Duration) Option {
+	return func(bl *Bricklink) {
+		bl.writeTimeout = d
+	}
+}
+
+// WithRequestInterceptor adds a function which is called with every request
+// before it is signed, e.g. for logging or adding headers. Interceptors run
+// in the order they were added. See OutgoingRequest for what can be changed.
+func WithRequestInterceptor(fn func(*OutgoingRequest)) Option {
+	return func(bl *Bricklink) {
+		bl.interceptors = append(bl.interceptors, fn)
+	}
+}
+
+// WithDefaultRegion sets the region the price methods (GetItemPrice,
+// GetPriceGuide and the helpers built on it) use unless a region or country
+// code is given per call, e.g. "europe".
+func WithDefaultRegion(region string) Option {
+	return func(bl *Bricklink) {
+		bl.defaultRegion = region
+	}
+}
+
+// WithDefaultCurrency sets the currency the price methods report prices in
+// unless a currency code is given per call, e.g. "EUR".
+func WithDefaultCurrency(currencyCode string) Option {
+	return func(bl *Bricklink) {
+		bl.defaultCurrency = currencyCode
+	}
+}
+
+// WithAutoPaginate toggles whether the ...All helpers follow the pagination
+// of list responses. It is enabled by default; when disabled only the first
+// page is returned.
+func WithAutoPaginate(enabled bool) Option {
+	return func(bl *Bricklink) {
+		bl.noAutoPaginate = !enabled
+	}
+}
@@ -0,0 +1,380 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Order is a store order as returned by GetOrderParsed.
+type Order struct {
+	OrderID           int       `json:"order_id"`
+	DateOrdered       time.Time `json:"date_ordered"`
+	DateStatusChanged time.Time `json:"date_status_changed"`
+	SellerName        string    `json:"seller_name"`
+	StoreName         string    `json:"store_name"`
+	BuyerName         string    `json:"buyer_name"`
+	BuyerEmail        string    `json:"buyer_email"`
+	Status            string    `json:"status"`
+	IsInvoiced        bool      `json:"is_invoiced"`
+	Remarks           string    `json:"remarks"`
+
+	// TotalCount is the total number of items, UniqueCount the number of
+	// distinct lots of the order
+	TotalCount  int `json:"total_count"`
+	UniqueCount int `json:"unique_count"`
+
+	// TotalWeight is the weight of the order in grams as reported by
+	// BrickLink, it may be empty or "0.00"
+	TotalWeight string `json:"total_weight"`
+
+	Payment  Payment   `json:"payment"`
+	Shipping Shipping  `json:"shipping"`
+	Cost     OrderCost `json:"cost"`
+
+	// DriveThruSent reports whether a drive thru email was already sent
+	// for the order. Check it before calling SendDriveThru to avoid
+	// sending duplicates.
+	DriveThruSent bool `json:"drive_thru_sent"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// Payment is the payment information of an order.
+type Payment struct {
+	// Method is the payment method, e.g. "PayPal"
+	Method       string        `json:"method"`
+	CurrencyCode string        `json:"currency_code"`
+	Status       PaymentStatus `json:"status"`
+
+	// DatePaid is the zero time for unpaid orders
+	DatePaid time.Time `json:"date_paid"`
+}
+
+// PaymentStatus is the payment status of an order, see PaymentStatuses.
+type PaymentStatus string
+
+// Payment statuses as used by BrickLink
+const (
+	PaymentNone      PaymentStatus = "None"
+	PaymentSent      PaymentStatus = "Sent"
+	PaymentReceived  PaymentStatus = "Received"
+	PaymentClearing  PaymentStatus = "Clearing"
+	PaymentReturned  PaymentStatus = "Returned"
+	PaymentBounced   PaymentStatus = "Bounced"
+	PaymentCompleted PaymentStatus = "Completed"
+)
+
+// UnmarshalJSON decodes a payment, parsing its timestamps leniently.
+func (p *Payment) UnmarshalJSON(b []byte) error {
+	type alias Payment
+	aux := struct {
+		*alias
+		DatePaid timestamp `json:"date_paid"`
+	}{alias: (*alias)(p)}
+
+	err := json.Unmarshal(b, &aux)
+	if err != nil {
+		return err
+	}
+	p.DatePaid = time.Time(aux.DatePaid)
+
+	return nil
+}
+
+// OrderCost holds the totals of an order, in the currency of the order.
+type OrderCost struct {
+	CurrencyCode string `json:"currency_code"`
+	Subtotal     Money  `json:"subtotal"`
+	GrandTotal   Money  `json:"grand_total"`
+	Etc1         Money  `json:"etc1"`
+	Etc2         Money  `json:"etc2"`
+	Insurance    Money  `json:"insurance"`
+	Shipping     Money  `json:"shipping"`
+	Credit       Money  `json:"credit"`
+	Coupon       Money  `json:"coupon"`
+	VATRate      string `json:"vat_rate"`
+	VATAmount    Money  `json:"vat_amount"`
+}
+
+// Shipping is the shipping information of an order.
+type Shipping struct {
+	MethodID     int             `json:"method_id"`
+	Method       string          `json:"method"`
+	TrackingNo   string          `json:"tracking_no"`
+	TrackingLink string          `json:"tracking_link"`
+	DateShipped  string          `json:"date_shipped"`
+	Address      ShippingAddress `json:"address"`
+}
+
+// ShippingAddress is the address an order is shipped to.
+type ShippingAddress struct {
+	Name struct {
+		Full  string `json:"full"`
+		First string `json:"first"`
+		Last  string `json:"last"`
+	} `json:"name"`
+	Full        string `json:"full"`
+	Address1    string `json:"address1"`
+	Address2    string `json:"address2"`
+	City        string `json:"city"`
+	State       string `json:"state"`
+	PostalCode  string `json:"postal_code"`
+	CountryCode string `json:"country_code"`
+}
+
+// GetOrders issues a GET request to the Bricklink API and querys for a list of
+// orders. Params are passed on as query parameters (e.g. direction, status).
+func (bl Bricklink) GetOrders(params map[string]string) (response string, err error) {
+	body, err := bl.GetOrdersBytes(params)
+	return string(body), err
+}
+
+// GetOrdersBytes is like GetOrders but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetOrdersBytes(params map[string]string) (response []byte, err error) {
+	body, err := bl.send(bl.context(), "GET", buildURI("/orders", params), nil)
+	if err != nil {
+		return response, err
+	}
+
+	return body, nil
+}
+
+// GetOrdersParsed querys for a list of orders and returns them parsed.
+func (bl Bricklink) GetOrdersParsed(params map[string]string) (orders []Order, err error) {
+	err = bl.getParsed(bl.context(), buildURI("/orders", params), &orders)
+	return orders, err
+}
+
+// GetOrder issues a GET request to the Bricklink API and querys for the specified order.
+func (bl Bricklink) GetOrder(orderID int) (response string, err error) {
+	body, err := bl.GetOrderBytes(orderID)
+	return string(body), err
+}
+
+// GetOrderBytes is like GetOrder but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetOrderBytes(orderID int) (response []byte, err error) {
+	// build uri
+	uri := "/orders/" + strconv.Itoa(orderID)
+
+	body, err := bl.send(bl.context(), "GET", uri, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return body, nil
+}
+
+// GetOrderParsed querys for the specified order and returns it parsed.
+func (bl Bricklink) GetOrderParsed(orderID int) (order Order, err error) {
+	return bl.order(bl.context(), orderID)
+}
+
+func (bl Bricklink) order(ctx context.Context, orderID int) (order Order, err error) {
+	// build uri
+	uri := "/orders/" + strconv.Itoa(orderID)
+
+	err = bl.getParsed(ctx, uri, &order)
+	return order, err
+}
+
+// GetOrdersDetailed fetches the orders with the given IDs concurrently, using
+// up to concurrency requests at a time (4 if concurrency is below 1). The
+// orders are returned in the order of ids; orders which could not be fetched
+// are left at their zero value and their errors are returned as MultiError.
+// Requests are throttled by the rate limiter and the concurrency limit of the
+// handler if configured.
+func (bl Bricklink) GetOrdersDetailed(ctx context.Context, ids []int, concurrency int) ([]Order, error) {
+	o := bl.batchOptions(nil)
+	if concurrency > 0 {
+		o.concurrency = concurrency
+	}
+
+	orders := make([]Order, len(ids))
+	errs, _ := runBatch(ctx, len(ids), o, func(ctx context.Context, i int) (err error) {
+		orders[i], err = bl.order(ctx, ids[i])
+		return err
+	})
+
+	var merr MultiError
+	for i, err := range errs {
+		if err != nil {
+			merr = append(merr, fmt.Errorf("order %v: %w", ids[i], err))
+		}
+	}
+
+	return orders, merr.errOrNil()
+}
+
+// SendDriveThru issues a POST request to the Bricklink API and sends a drive
+// thru email for the specified order. If mailMe is set, a copy is sent to the
+// seller as well. BrickLink does not prevent duplicates, see Order.DriveThruSent.
+func (bl Bricklink) SendDriveThru(orderID int, mailMe bool) (response string, err error) {
+	body, err := bl.send(bl.context(), "POST", driveThruURI(orderID, mailMe), nil)
+	if err != nil {
+		return response, err
+	}
+
+	return string(body), nil
+}
+
+// DriveThruResult is the confirmation of a drive thru request.
+type DriveThruResult struct {
+	// Queued reports whether BrickLink accepted the email for sending
+	Queued bool
+	// Message is the message of the response meta block
+	Message string
+}
+
+// SendDriveThruParsed sends a drive thru email like SendDriveThru and returns
+// the parsed confirmation. Failures reported by BrickLink are returned as
+// *BrickLinkError.
+func (bl Bricklink) SendDriveThruParsed(orderID int, mailMe bool) (result DriveThruResult, err error) {
+	body, err := bl.send(bl.context(), "POST", driveThruURI(orderID, mailMe), nil)
+	if err != nil {
+		return result, err
+	}
+
+	meta, err := bl.decodeMeta(body)
+	if err != nil {
+		return result, err
+	}
+
+	result.Queued = true
+	result.Message = meta.Message
+
+	return result, nil
+}
+
+// helper function to build the uri of a drive thru request
+func driveThruURI(orderID int, mailMe bool) string {
+	uri := "/orders/" + strconv.Itoa(orderID) + "/drive_thru"
+	if mailMe {
+		uri += "?mail_me=true"
+	}
+	return uri
+}
+
+// UpdateOrderStatus issues a PUT request to the Bricklink API and sets the
+// status of the specified order. Failures reported by BrickLink are returned
+// as *BrickLinkError.
+//
+// With WithRetry and WithVerifiedWrites set, an ambiguous failure is followed
+// by a GET of the order and the update is only retried if the order doesn't
+// have the status yet.
+func (bl Bricklink) UpdateOrderStatus(orderID int, status string) error {
+	err := bl.validParam(status, orderStatuses)
+	if err != nil {
+		return err
+	}
+
+	return bl.updateOrderField(bl.context(), orderID, "status", status, func(o Order) bool {
+		return strings.EqualFold(o.Status, status)
+	})
+}
+
+// UpdatePaymentStatus issues a PUT request to the Bricklink API and sets the
+// payment status of the specified order, e.g. PaymentReceived. Retries behave
+// as documented on UpdateOrderStatus.
+func (bl Bricklink) UpdatePaymentStatus(orderID int, status PaymentStatus) error {
+	err := bl.validParam(string(status), paymentStatuses)
+	if err != nil {
+		return err
+	}
+
+	return bl.updateOrderField(bl.context(), orderID, "payment_status", string(status), func(o Order) bool {
+		return strings.EqualFold(string(o.Payment.Status), string(status))
+	})
+}
+
+// updateOrderField sends an order field update. If verified writes are
+// enabled, applied is used to check a freshly fetched order before retrying;
+// if the order can't be fetched the write error is returned, as it is
+// unknown whether the write was applied.
+func (bl Bricklink) updateOrderField(ctx context.Context, orderID int, field, value string, applied func(Order) bool) error {
+	// build uri and payload
+	uri := "/orders/" + strconv.Itoa(orderID) + "/" + field
+	payload, err := json.Marshal(map[string]string{"field": field, "value": value})
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		body, err := bl.sendOnce(ctx, "PUT", uri, payload)
+		if err == nil {
+			return bl.decode(body, nil)
+		}
+		if !bl.verifyWrites || attempt >= bl.retries || !retryable(ctx, err) {
+			return err
+		}
+
+		werr := bl.wait(ctx, attempt)
+		if werr != nil {
+			return werr
+		}
+
+		// verify the write wasn't applied before sending it again
+		order, verr := bl.freshOrder(ctx, orderID)
+		if verr != nil {
+			return fmt.Errorf("%w (could not verify the write: %v)", err, verr)
+		}
+		if applied(order) {
+			return nil
+		}
+	}
+}
+
+// freshOrder fetches the order bypassing the cache, where a copy could be
+// outdated
+func (bl Bricklink) freshOrder(ctx context.Context, orderID int) (order Order, err error) {
+	body, err := bl.send(ctx, "GET", "/orders/"+strconv.Itoa(orderID), nil)
+	if err != nil {
+		return order, err
+	}
+
+	err = bl.decode(body, &order)
+	return order, err
+}
+
+var (
+	// cancellableStatuses are the order statuses an order can be cancelled from
+	cancellableStatuses = []string{"PENDING", "UPDATED", "PROCESSING", "READY", "PAID", "PACKED", "OCR", "NPB", "NPX", "NRS", "NSS"}
+	// purgeableStatuses are the order statuses an order can be purged from
+	purgeableStatuses = []string{"CANCELLED"}
+)
+
+// CancelOrder sets the status of the specified order to CANCELLED. The
+// current status is checked first: orders which were already shipped,
+// received, completed or purged can't be cancelled.
+func (bl Bricklink) CancelOrder(orderID int) error {
+	return bl.transitionOrder(bl.context(), orderID, "CANCELLED", cancellableStatuses)
+}
+
+// PurgeOrder sets the status of the specified order to PURGED, which removes
+// it from the order lists. Only cancelled orders can be purged.
+func (bl Bricklink) PurgeOrder(orderID int) error {
+	return bl.transitionOrder(bl.context(), orderID, "PURGED", purgeableStatuses)
+}
+
+// transitionOrder sets the order status to status if the current status is
+// one of from
+func (bl Bricklink) transitionOrder(ctx context.Context, orderID int, status string, from []string) error {
+	order, err := bl.freshOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if !stringInSlice(order.Status, from) {
+		return fmt.Errorf("order %v can't be set from status \"%v\" to \"%v\"", orderID, order.Status, status)
+	}
+
+	return bl.updateOrderField(ctx, orderID, "status", status, func(o Order) bool {
+		return strings.EqualFold(o.Status, status)
+	})
+}
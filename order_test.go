@@ -0,0 +1,120 @@
+package bricklinkapi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDecodeOrder(t *testing.T) {
+	body := []byte(`{"meta":{"code":200},"data":{"order_id":3986441,"date_ordered":"2013-12-30T22:48:11.547Z",
+		"status":"PAID","total_count":12,"unique_count":3,"drive_thru_sent":true,
+		"cost":{"currency_code":"USD","grand_total":"10.5000"}}}`)
+
+	var order Order
+	err := decode(body, &order)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if order.TotalCount != 12 || order.UniqueCount != 3 {
+		t.Errorf("\ncounts, want: 12/3, got: %v/%v\n", order.TotalCount, order.UniqueCount)
+	}
+	if !order.DriveThruSent || order.Cost.GrandTotal.Amount != 105000 || order.DateOrdered.Year() != 2013 {
+		t.Errorf("\nunexpected order: %+v\n", order)
+	}
+}
+
+func TestDecodeOrderPayment(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		payment string
+		exp     Payment
+	}{
+		{desc: "testing paid",
+			payment: `{"method":"PayPal","currency_code":"EUR","date_paid":"2013-12-31T10:02:01.000Z","status":"Received"}`,
+			exp:     Payment{Method: "PayPal", CurrencyCode: "EUR", Status: PaymentReceived, DatePaid: time.Date(2013, 12, 31, 10, 2, 1, 0, time.UTC)}},
+		{desc: "testing unpaid",
+			payment: `{"method":"PayPal","currency_code":"EUR","status":"None"}`,
+			exp:     Payment{Method: "PayPal", CurrencyCode: "EUR", Status: PaymentNone}},
+		{desc: "testing null date",
+			payment: `{"method":"PayPal","date_paid":null,"status":"None"}`,
+			exp:     Payment{Method: "PayPal", Status: PaymentNone}},
+	}
+	for _, tc := range testCases {
+		var order Order
+		err := decode([]byte(`{"meta":{"code":200},"data":{"order_id":1,"payment":`+tc.payment+`}}`), &order)
+		if err != nil {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if !order.Payment.DatePaid.Equal(tc.exp.DatePaid) || order.Payment.Method != tc.exp.Method ||
+			order.Payment.CurrencyCode != tc.exp.CurrencyCode || order.Payment.Status != tc.exp.Status {
+			t.Errorf("%v, want: %+v, got: %+v\n", tc.desc, tc.exp, order.Payment)
+		}
+	}
+}
+
+func TestUpdatePaymentStatus(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		status PaymentStatus
+		expS   string
+	}{
+		{desc: "testing valid status", status: PaymentReceived, expS: `{"field":"payment_status","value":"Received"}`},
+		{desc: "testing invalid status", status: "Paid", expS: ""},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "")
+		f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{}}`)}
+		bl.request = f
+
+		err := bl.UpdatePaymentStatus(1, tc.status)
+		if (err == nil) != (tc.expS != "") || string(f.payload) != tc.expS {
+			t.Errorf("%v, want: %v, got: %s (%v)\n", tc.desc, tc.expS, f.payload, err)
+		}
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		status string
+		ok     bool
+	}{
+		{desc: "testing paid order", status: "PAID", ok: true},
+		{desc: "testing shipped order", status: "SHIPPED", ok: false},
+	}
+	for _, tc := range testCases {
+		s := &scriptedRequest{results: []scriptedResult{
+			{body: `{"meta":{"code":200},"data":{"order_id":1,"status":"` + tc.status + `"}}`},
+			{body: `{"meta":{"code":200},"data":{}}`},
+		}}
+		bl := New("", "", "", "")
+		bl.request = s
+
+		err := bl.CancelOrder(1)
+		if (err == nil) != tc.ok {
+			t.Errorf("\n%v, want ok: %v, got: %v\n", tc.desc, tc.ok, err)
+		}
+		if !tc.ok && len(s.methods) != 1 {
+			t.Errorf("\n%v, illegal transition must not be sent, got: %v\n", tc.desc, s.methods)
+		}
+	}
+}
+
+func TestGetOrdersDetailed(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/orders/1": `{"meta":{"code":200},"data":{"order_id":1}}`,
+		"/orders/2": `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`,
+		"/orders/3": `{"meta":{"code":200},"data":{"order_id":3}}`,
+	}
+
+	orders, err := bl.GetOrdersDetailed(context.Background(), []int{3, 2, 1}, 2)
+	merr, ok := err.(MultiError)
+	if !ok || len(merr) != 1 {
+		t.Errorf("\nwant one error, got: %v\n", err)
+	}
+	if len(orders) != 3 || orders[0].OrderID != 3 || orders[1].OrderID != 0 || orders[2].OrderID != 1 {
+		t.Errorf("\norders should keep the input order, got: %+v\n", orders)
+	}
+}
@@ -0,0 +1,119 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OrderItem is a single lot of an order.
+type OrderItem struct {
+	InventoryID    int    `json:"inventory_id"`
+	Item           Item   `json:"item"`
+	ColorID        int    `json:"color_id"`
+	ColorName      string `json:"color_name"`
+	Quantity       int    `json:"quantity"`
+	NewOrUsed      string `json:"new_or_used"`
+	UnitPrice      Money  `json:"unit_price"`
+	UnitPriceFinal Money  `json:"unit_price_final"`
+	CurrencyCode   string `json:"currency_code"`
+	Weight         string `json:"weight"`
+
+	// Description and Remarks are copied from the lot at the time of the
+	// order, Remarks often hold the storage location. They are empty if the
+	// lot had none.
+	Description string `json:"description"`
+	Remarks     string `json:"remarks"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// EnrichedOrderItem is an order item combined with its catalog entry.
+type EnrichedOrderItem struct {
+	OrderItem
+	Catalog CatalogItem
+}
+
+// GetOrderItems issues a GET request to the Bricklink API and querys for the
+// items of the specified order.
+func (bl Bricklink) GetOrderItems(orderID int) (response string, err error) {
+	body, err := bl.GetOrderItemsBytes(orderID)
+	return string(body), err
+}
+
+// GetOrderItemsBytes is like GetOrderItems but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetOrderItemsBytes(orderID int) (response []byte, err error) {
+	// build uri
+	uri := "/orders/" + strconv.Itoa(orderID) + "/items"
+
+	body, err := bl.send(bl.context(), "GET", uri, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return body, nil
+}
+
+// GetOrderItemsParsed querys for the items of the specified order and returns
+// them parsed. Items are grouped in batches, as BrickLink groups them.
+func (bl Bricklink) GetOrderItemsParsed(orderID int) (batches [][]OrderItem, err error) {
+	return bl.orderItems(bl.context(), orderID)
+}
+
+func (bl Bricklink) orderItems(ctx context.Context, orderID int) (batches [][]OrderItem, err error) {
+	// build uri
+	uri := "/orders/" + strconv.Itoa(orderID) + "/items"
+
+	err = bl.getParsed(ctx, uri, &batches)
+	return batches, err
+}
+
+// EnrichOrderItems fetches the catalog entries of the given order items
+// concurrently and returns the items combined with them. Every distinct item
+// is fetched once; requests are served from the cache and throttled by the
+// rate limiter if configured. Items whose catalog entry could not be fetched
+// are returned without it and their errors are returned as MultiError.
+func (bl Bricklink) EnrichOrderItems(ctx context.Context, items []OrderItem) ([]EnrichedOrderItem, error) {
+	// collect distinct items
+	var keys []Item
+	index := make(map[string]int)
+	for _, it := range items {
+		k := itemKey(it.Item)
+		if _, ok := index[k]; !ok {
+			index[k] = len(keys)
+			keys = append(keys, it.Item)
+		}
+	}
+
+	catalog := make([]CatalogItem, len(keys))
+	errs, _ := runBatch(ctx, len(keys), bl.batchOptions(nil), func(ctx context.Context, i int) (err error) {
+		catalog[i], err = bl.catalogItem(ctx, keys[i].Type, keys[i].No)
+		return err
+	})
+
+	var merr MultiError
+	for i, err := range errs {
+		if err != nil {
+			merr = append(merr, fmt.Errorf("item %v %v: %w", keys[i].Type, keys[i].No, err))
+		}
+	}
+
+	enriched := make([]EnrichedOrderItem, len(items))
+	for i, it := range items {
+		enriched[i] = EnrichedOrderItem{
+			OrderItem: it,
+			Catalog:   catalog[index[itemKey(it.Item)]],
+		}
+	}
+
+	return enriched, merr.errOrNil()
+}
+
+// helper function to build a map key identifying an item
+func itemKey(item Item) string {
+	return strings.ToUpper(item.Type) + "/" + strings.ToUpper(item.No)
+}
@@ -0,0 +1,46 @@
+package bricklinkapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEnrichOrderItems(t *testing.T) {
+	f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"no":"3001","name":"Brick 2 x 4","type":"PART"}}`)}
+	bl := New("", "", "", "")
+	bl.request = f
+
+	items := []OrderItem{
+		{Item: Item{No: "3001", Type: "PART"}, ColorID: 1},
+		{Item: Item{No: "3001", Type: "PART"}, ColorID: 5},
+	}
+	enriched, err := bl.EnrichOrderItems(context.Background(), items)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if f.calls != 1 {
+		t.Errorf("\ndistinct items should be fetched once, got: %v requests\n", f.calls)
+	}
+	for i, e := range enriched {
+		if e.Catalog.Name != "Brick 2 x 4" || e.ColorID != items[i].ColorID {
+			t.Errorf("\nitem %v not enriched, got: %+v\n", i, e)
+		}
+	}
+}
+
+func TestDecodeOrderItemRemarks(t *testing.T) {
+	var batches [][]OrderItem
+	err := decode([]byte(`{"meta":{"code":200},"data":[[
+		{"inventory_id":1,"item":{"no":"3001","type":"PART"},"description":"like new","remarks":"A12"},
+		{"inventory_id":2,"item":{"no":"3002","type":"PART"}}]]}`), &batches)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	items := batches[0]
+	if items[0].Description != "like new" || items[0].Remarks != "A12" || items[1].Remarks != "" {
+		t.Errorf("\nunexpected items: %+v\n", items)
+	}
+	if len(items[0].Extras) != 0 {
+		t.Errorf("\nmodeled fields should not be captured, got: %v\n", items[0].Extras)
+	}
+}
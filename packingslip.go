@@ -0,0 +1,49 @@
+package bricklinkapi
+
+import (
+	"context"
+)
+
+// PackingSlip holds everything needed to render a packing slip for an order.
+type PackingSlip struct {
+	Order   Order
+	Address ShippingAddress
+	Items   []EnrichedOrderItem
+
+	// Warnings lists the problems which didn't prevent building the slip,
+	// e.g. items whose catalog entry could not be fetched.
+	Warnings []string
+}
+
+// BuildPackingSlip fetches the order, its items and their catalog entries and
+// combines them into a packing slip. If only the catalog enrichment fails for
+// some items, the slip is returned with these items unenriched and the
+// failures listed in Warnings.
+func (bl Bricklink) BuildPackingSlip(ctx context.Context, orderID int) (slip PackingSlip, err error) {
+	slip.Order, err = bl.order(ctx, orderID)
+	if err != nil {
+		return slip, err
+	}
+	slip.Address = slip.Order.Shipping.Address
+
+	batches, err := bl.orderItems(ctx, orderID)
+	if err != nil {
+		return slip, err
+	}
+
+	var items []OrderItem
+	for _, batch := range batches {
+		items = append(items, batch...)
+	}
+
+	slip.Items, err = bl.EnrichOrderItems(ctx, items)
+	if merr, ok := err.(MultiError); ok {
+		for _, e := range merr {
+			slip.Warnings = append(slip.Warnings, e.Error())
+		}
+	} else if err != nil {
+		return slip, err
+	}
+
+	return slip, nil
+}
@@ -0,0 +1,40 @@
+package bricklinkapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBuildPackingSlip(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/orders/1": `{"meta":{"code":200},"data":{"order_id":1,"shipping":{"address":{"name":{"full":"Jane Doe"},"city":"Berlin"}}}}`,
+		"/orders/1/items": `{"meta":{"code":200},"data":[
+			[{"item":{"no":"3001","type":"PART"},"quantity":2}],
+			[{"item":{"no":"3002","type":"PART"},"quantity":1}]]}`,
+		"/items/PART/3001": `{"meta":{"code":200},"data":{"no":"3001","name":"Brick 2 x 4","type":"PART"}}`,
+		"/items/PART/3002": `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`,
+	}
+
+	slip, err := bl.BuildPackingSlip(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if slip.Order.OrderID != 1 || slip.Address.Name.Full != "Jane Doe" || slip.Address.City != "Berlin" {
+		t.Errorf("\nunexpected order or address: %+v, %+v\n", slip.Order, slip.Address)
+	}
+	if len(slip.Items) != 2 || slip.Items[0].Catalog.Name != "Brick 2 x 4" || slip.Items[1].Catalog.No != "" {
+		t.Errorf("\nunexpected items: %+v\n", slip.Items)
+	}
+	if len(slip.Warnings) != 1 || !strings.HasPrefix(slip.Warnings[0], "item PART 3002:") {
+		t.Errorf("\nwant a warning for PART 3002, got: %v\n", slip.Warnings)
+	}
+
+	// failing to fetch the order itself is an error
+	bl.request = routeRequest{"/orders/1": `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`}
+	_, err = bl.BuildPackingSlip(context.Background(), 1)
+	if err == nil {
+		t.Errorf("\nmissing order, want error, got nil\n")
+	}
+}
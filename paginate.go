@@ -0,0 +1,122 @@
+package bricklinkapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// maxPages guards the pagination against servers returning cursors forever
+const maxPages = 10000
+
+// getAll fetches a list into v, which must point to a slice. If the response
+// points to a next page and auto pagination is enabled (the default), the
+// following pages are fetched and appended until no cursor is returned or,
+// if the total count is reported, all entries were fetched. Pages are not
+// cached.
+func (bl Bricklink) getAll(ctx context.Context, uri string, params map[string]string, v interface{}) error {
+	defer emptySlice(v)
+
+	list := reflect.ValueOf(v).Elem()
+	page := reflect.New(list.Type())
+	return bl.eachPage(ctx, uri, params, page.Interface(), func() error {
+		list.Set(reflect.AppendSlice(list, page.Elem()))
+		return nil
+	})
+}
+
+// eachPage fetches the pages of a list like getAll, decoding each page into
+// page, which must point to a slice, and calling fn after every page. Only a
+// single page is held at a time.
+func (bl Bricklink) eachPage(ctx context.Context, uri string, params map[string]string, page interface{}, fn func() error) error {
+	items := reflect.ValueOf(page).Elem()
+	return bl.eachPageBody(ctx, uri, params, func(body []byte) (Meta, int, error) {
+		items.Set(reflect.Zero(items.Type()))
+		meta, err := bl.decodeWithMeta(body, page)
+		if err != nil {
+			return meta, 0, err
+		}
+		return meta, items.Len(), fn()
+	})
+}
+
+// eachPageBody fetches the pages of a list like eachPage, but passes the
+// response body of every page to fn, which returns the meta block of the
+// page and the number of its entries.
+func (bl Bricklink) eachPageBody(ctx context.Context, uri string, params map[string]string, fn func(body []byte) (Meta, int, error)) error {
+	p := make(map[string]string, len(params)+1)
+	for k, val := range params {
+		p[k] = val
+	}
+
+	var count int
+	seen := make(map[string]bool)
+	for n := 0; n < maxPages; n++ {
+		body, err := bl.send(ctx, "GET", buildURI(uri, p), nil)
+		if err != nil {
+			return err
+		}
+
+		meta, entries, err := fn(body)
+		if err != nil {
+			return err
+		}
+		count += entries
+
+		if bl.noAutoPaginate || meta.NextCursor == "" {
+			return nil
+		}
+		if meta.TotalCount > 0 && count >= meta.TotalCount {
+			return nil
+		}
+		if seen[meta.NextCursor] {
+			return fmt.Errorf("pagination of %v returned cursor \"%v\" twice", uri, meta.NextCursor)
+		}
+		seen[meta.NextCursor] = true
+		p["cursor"] = meta.NextCursor
+	}
+
+	return fmt.Errorf("pagination of %v exceeded %v pages", uri, maxPages)
+}
+
+// GetInventoryListAll fetches the complete store inventory, following the
+// pagination of the response if present (see WithAutoPaginate).
+func (bl Bricklink) GetInventoryListAll(ctx context.Context, params map[string]string) (inventories []Inventory, err error) {
+	err = bl.getAll(ctx, "/inventories", params, &inventories)
+	return inventories, err
+}
+
+// GetOrdersAll fetches the complete list of orders, following the pagination
+// of the response if present (see WithAutoPaginate).
+func (bl Bricklink) GetOrdersAll(ctx context.Context, params map[string]string) (orders []Order, err error) {
+	err = bl.getAll(ctx, "/orders", params, &orders)
+	return orders, err
+}
+
+// GetInventoryListWithMeta querys for the store inventory and returns the
+// parsed lots of a single page along with the meta block, which holds the
+// pagination (NextCursor, TotalCount, Page) if reported by BrickLink.
+func (bl Bricklink) GetInventoryListWithMeta(params map[string]string) (inventories []Inventory, meta Meta, err error) {
+	meta, err = bl.getWithMeta(bl.context(), buildURI("/inventories", params), &inventories)
+	return inventories, meta, err
+}
+
+// GetOrdersWithMeta querys for orders and returns the parsed orders of a
+// single page along with the meta block, see GetInventoryListWithMeta.
+func (bl Bricklink) GetOrdersWithMeta(params map[string]string) (orders []Order, meta Meta, err error) {
+	meta, err = bl.getWithMeta(bl.context(), buildURI("/orders", params), &orders)
+	return orders, meta, err
+}
+
+// getWithMeta issues a GET request bypassing the cache, which doesn't keep
+// the meta block, and decodes the response data into v
+func (bl Bricklink) getWithMeta(ctx context.Context, uri string, v interface{}) (Meta, error) {
+	defer emptySlice(v)
+
+	body, err := bl.send(ctx, "GET", uri, nil)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return bl.decodeWithMeta(body, v)
+}
@@ -0,0 +1,81 @@
+package bricklinkapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetAll(t *testing.T) {
+	pages := []scriptedResult{
+		{body: `{"meta":{"code":200,"next_cursor":"a"},"data":[{"inventory_id":1}]}`},
+		{body: `{"meta":{"code":200,"next_cursor":"b"},"data":[{"inventory_id":2}]}`},
+		{body: `{"meta":{"code":200},"data":[{"inventory_id":3}]}`},
+	}
+
+	testCases := []struct {
+		desc     string
+		enabled  bool
+		expCount int
+	}{
+		{desc: "testing auto pagination", enabled: true, expCount: 3},
+		{desc: "testing disabled pagination", enabled: false, expCount: 1},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "", WithAutoPaginate(tc.enabled))
+		bl.request = &scriptedRequest{results: append([]scriptedResult(nil), pages...)}
+
+		inventories, err := bl.GetInventoryListAll(context.Background(), nil)
+		if err != nil {
+			t.Errorf("\n%v, unexpected error: %v\n", tc.desc, err)
+			continue
+		}
+		if len(inventories) != tc.expCount || inventories[0].InventoryID != 1 {
+			t.Errorf("\n%v, want: %v lots, got: %+v\n", tc.desc, tc.expCount, inventories)
+		}
+	}
+}
+
+func TestGetAllRepeatedCursor(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = &scriptedRequest{results: []scriptedResult{
+		{body: `{"meta":{"code":200,"next_cursor":"a"},"data":[]}`},
+		{body: `{"meta":{"code":200,"next_cursor":"a"},"data":[]}`},
+	}}
+
+	_, err := bl.GetOrdersAll(context.Background(), nil)
+	if err == nil {
+		t.Errorf("\nrepeated cursor, want error, got nil\n")
+	}
+}
+
+func TestGetAllTotalCount(t *testing.T) {
+	s := &scriptedRequest{results: []scriptedResult{
+		{body: `{"meta":{"code":200,"next_cursor":"a","total_count":3,"page":1},"data":[{"order_id":1},{"order_id":2}]}`},
+		{body: `{"meta":{"code":200,"next_cursor":"b","total_count":3,"page":2},"data":[{"order_id":3}]}`},
+	}}
+	bl := New("", "", "", "")
+	bl.request = s
+
+	orders, err := bl.GetOrdersAll(context.Background(), nil)
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	if len(orders) != 3 || len(s.methods) != 2 {
+		t.Errorf("\nwant: 3 orders in 2 requests, got: %v in %v\n", len(orders), len(s.methods))
+	}
+}
+
+func TestGetOrdersWithMeta(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = &scriptedRequest{results: []scriptedResult{
+		{body: `{"meta":{"code":200,"next_cursor":"a","total_count":3,"page":1},"data":[{"order_id":1}]}`},
+	}}
+
+	orders, meta, err := bl.GetOrdersWithMeta(nil)
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	if len(orders) != 1 || meta.TotalCount != 3 || meta.Page != 1 || meta.NextCursor != "a" {
+		t.Errorf("\nunexpected result: %+v %+v\n", orders, meta)
+	}
+}
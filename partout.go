@@ -0,0 +1,129 @@
+package bricklinkapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PartOutValue is the estimated value of a set when parted out.
+type PartOutValue struct {
+	// Total is the sum of the values of all parts
+	Total Money
+	Parts []PartValue
+}
+
+// PartValue is the estimated value of a single part of a set.
+type PartValue struct {
+	SubsetItem
+	PriceGuide PriceGuide
+	// Value is the average price of the guide times the quantity
+	Value Money
+}
+
+// GetSetPartsValue expands the set into its parts, fetches the price guide of
+// every part and color concurrently and returns the estimated part out value.
+// Parts occurring in several subsets are combined into one entry, so every
+// guide is fetched once. opts apply to every price guide, the color is taken
+// from the part. Minifigs are not broken up but priced by their own guide,
+// without color. Extra parts and alternates are not counted. Requests are
+// served from the cache and throttled by the rate limiter if configured.
+//
+// Parts whose price guide could not be fetched, or is reported in another
+// currency than the other guides, are returned without value and their
+// errors are returned as MultiError.
+func (bl Bricklink) GetSetPartsValue(setNumber string, opts PriceGuideOptions) (PartOutValue, error) {
+	return bl.setPartsValue(bl.context(), setNumber, opts)
+}
+
+func (bl Bricklink) setPartsValue(ctx context.Context, setNumber string, opts PriceGuideOptions) (PartOutValue, error) {
+	value, errs, err := bl.pricedParts(ctx, setNumber, opts)
+	if err != nil {
+		return value, err
+	}
+
+	var merr MultiError
+	for _, err := range errs {
+		if err != nil {
+			merr = append(merr, err)
+		}
+	}
+
+	return value, merr.errOrNil()
+}
+
+// pricedParts expands the set and prices its parts. The error of each part,
+// if any, is returned at its index of value.Parts; failed parts are not
+// counted in the total.
+func (bl Bricklink) pricedParts(ctx context.Context, setNumber string, opts PriceGuideOptions) (value PartOutValue, errs []error, err error) {
+	uri, err := bl.subsetsURI("SET", setNumber, nil)
+	if err != nil {
+		return value, nil, err
+	}
+
+	var subsets []Subset
+	err = bl.getParsed(ctx, uri, &subsets)
+	if err != nil {
+		return value, nil, err
+	}
+
+	parts := combineParts(flattenSubsets(subsets))
+	value.Parts = make([]PartValue, len(parts))
+	errs, _ = runBatch(ctx, len(parts), bl.batchOptions(nil), func(ctx context.Context, i int) error {
+		p := parts[i]
+		o := opts
+		o.ColorID = p.ColorID
+		if strings.EqualFold(p.Item.Type, "MINIFIG") {
+			// minifigs are priced as a whole, their guide has no color
+			o.ColorID = 0
+		}
+
+		guide, err := bl.priceGuide(ctx, p.Item.Type, p.Item.No, o)
+		value.Parts[i] = PartValue{SubsetItem: p, PriceGuide: guide}
+		if err != nil {
+			return err
+		}
+
+		value.Parts[i].Value = Money{
+			Amount:   guide.AvgPrice.Amount * int64(p.Quantity),
+			Currency: guide.CurrencyCode,
+		}
+		return nil
+	})
+
+	for i, err := range errs {
+		p := value.Parts[i]
+		if err == nil && value.Total.Currency != "" && !strings.EqualFold(p.Value.Currency, value.Total.Currency) {
+			err = fmt.Errorf("currency %v differs from %v", p.Value.Currency, value.Total.Currency)
+			value.Parts[i].Value = Money{}
+		}
+		if err != nil {
+			errs[i] = fmt.Errorf("part %v %v color %v: %w", p.Item.Type, p.Item.No, p.ColorID, err)
+			continue
+		}
+		value.Total.Amount += p.Value.Amount
+		value.Total.Currency = p.Value.Currency
+	}
+
+	return value, errs, nil
+}
+
+// combineParts sums up the quantities of entries with the same item and
+// color, keeping the order of their first occurrence
+func combineParts(parts []SubsetItem) []SubsetItem {
+	var combined []SubsetItem
+	index := make(map[string]int)
+	for _, p := range parts {
+		k := itemKey(p.Item) + "/" + strconv.Itoa(p.ColorID)
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(combined)
+			combined = append(combined, p)
+			continue
+		}
+		combined[i].Quantity += p.Quantity
+		combined[i].ExtraQuantity += p.ExtraQuantity
+	}
+	return combined
+}
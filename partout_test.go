@@ -0,0 +1,48 @@
+package bricklinkapi
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGetSetPartsValue(t *testing.T) {
+	r := &recordingRequest{routes: routeRequest{
+		"/items/SET/6020-1/subsets": `{"meta":{"code":200},"data":[
+			{"entries":[{"item":{"no":"3001","type":"PART"},"color_id":5,"quantity":4}]},
+			{"entries":[{"item":{"no":"sw0001","type":"MINIFIG"},"color_id":0,"quantity":1}]},
+			{"entries":[{"item":{"no":"3002","type":"PART"},"color_id":1,"quantity":1}]},
+			{"entries":[{"item":{"no":"3001","type":"PART"},"color_id":5,"quantity":2}]}]}`,
+		"/items/PART/3001/price":      `{"meta":{"code":200},"data":{"currency_code":"USD","avg_price":"0.2500"}}`,
+		"/items/MINIFIG/sw0001/price": `{"meta":{"code":200},"data":{"currency_code":"USD","avg_price":"3.0000"}}`,
+		"/items/PART/3002/price":      `{"meta":{"code":200},"data":{"currency_code":"EUR","avg_price":"1.0000"}}`,
+	}}
+	bl := New("", "", "", "", WithMaxConcurrency(1))
+	bl.request = r
+
+	value, err := bl.GetSetPartsValue("6020-1", PriceGuideOptions{})
+	if err == nil || !strings.Contains(err.Error(), "part PART 3002 color 1: currency EUR differs from USD") {
+		t.Errorf("\nwant currency error, got: %v\n", err)
+	}
+
+	// 4+2 parts at 0.25 and a minifig at 3.00, the EUR part isn't counted
+	want := Money{Amount: 45000, Currency: "USD"}
+	if value.Total != want {
+		t.Errorf("\nwant total: %+v, got: %+v\n", want, value.Total)
+	}
+	if len(value.Parts) != 3 || value.Parts[0].Quantity != 6 {
+		t.Errorf("\nwant 3 parts with 6 times 3001, got: %+v\n", value.Parts)
+	}
+
+	// every guide is fetched once, the minifig's without color
+	sort.Strings(r.uris)
+	wantURIs := []string{
+		"/items/MINIFIG/sw0001/price?new_or_used=N",
+		"/items/PART/3001/price?color_id=5&new_or_used=N",
+		"/items/PART/3002/price?color_id=1&new_or_used=N",
+		"/items/SET/6020-1/subsets",
+	}
+	if strings.Join(r.uris, "\n") != strings.Join(wantURIs, "\n") {
+		t.Errorf("\nwant requests:\n%v\ngot:\n%v\n", strings.Join(wantURIs, "\n"), strings.Join(r.uris, "\n"))
+	}
+}
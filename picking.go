@@ -0,0 +1,71 @@
+package bricklinkapi
+
+import (
+	"sort"
+	"strings"
+)
+
+// ColorGroup holds the order items of one color, see PickingList.
+type ColorGroup struct {
+	ColorID   int
+	ColorName string
+	Items     []OrderItem
+}
+
+// GroupOrderItemsByColor groups the order items, as returned by
+// GetOrderItemsParsed, by color ID. The items of each color are sorted by
+// category, then by item number, for picking from storage organized by
+// color.
+func GroupOrderItemsByColor(batches [][]OrderItem) map[int][]OrderItem {
+	groups := make(map[int][]OrderItem)
+	for _, batch := range batches {
+		for _, item := range batch {
+			groups[item.ColorID] = append(groups[item.ColorID], item)
+		}
+	}
+
+	for _, items := range groups {
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Item.CategoryID != items[j].Item.CategoryID {
+				return items[i].Item.CategoryID < items[j].Item.CategoryID
+			}
+			return items[i].Item.No < items[j].Item.No
+		})
+	}
+
+	return groups
+}
+
+// PickingList groups the order items by color like GroupOrderItemsByColor
+// and returns the groups as picking sequence, sorted by color name. The
+// names are resolved from the color list, which is served from the cache if
+// caching is enabled; the name reported with the items is used as fallback.
+func (bl Bricklink) PickingList(batches [][]OrderItem) ([]ColorGroup, error) {
+	colors, err := bl.GetColorListParsed()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(colors))
+	for _, c := range colors {
+		names[c.ColorID] = c.ColorName
+	}
+
+	var list []ColorGroup
+	for id, items := range GroupOrderItemsByColor(batches) {
+		name, ok := names[id]
+		if !ok {
+			name = items[0].ColorName
+		}
+		list = append(list, ColorGroup{ColorID: id, ColorName: name, Items: items})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		ni, nj := strings.ToLower(list[i].ColorName), strings.ToLower(list[j].ColorName)
+		if ni != nj {
+			return ni < nj
+		}
+		return list[i].ColorID < list[j].ColorID
+	})
+
+	return list, nil
+}
@@ -0,0 +1,39 @@
+package bricklinkapi
+
+import (
+	"testing"
+)
+
+func TestGroupOrderItemsByColor(t *testing.T) {
+	batches := [][]OrderItem{
+		{
+			{Item: Item{No: "3001", CategoryID: 5}, ColorID: 5},
+			{Item: Item{No: "3023", CategoryID: 26}, ColorID: 1},
+			{Item: Item{No: "3002", CategoryID: 5}, ColorID: 5},
+		},
+		{
+			{Item: Item{No: "2412b", CategoryID: 3}, ColorID: 5},
+			{Item: Item{No: "3003", CategoryID: 5}, ColorID: 99, ColorName: "Mystery"},
+		},
+	}
+
+	groups := GroupOrderItemsByColor(batches)
+	red := groups[5]
+	if len(groups) != 3 || len(red) != 3 || red[0].Item.No != "2412b" || red[1].Item.No != "3001" || red[2].Item.No != "3002" {
+		t.Errorf("\nunexpected groups: %+v\n", groups)
+	}
+
+	bl := New("", "", "", "")
+	bl.request = &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[{"color_id":1,"color_name":"White"},{"color_id":5,"color_name":"Red"}]}`)}
+	list, err := bl.PickingList(batches)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	var names []string
+	for _, g := range list {
+		names = append(names, g.ColorName)
+	}
+	if len(names) != 3 || names[0] != "Mystery" || names[1] != "Red" || names[2] != "White" {
+		t.Errorf("\nunexpected picking sequence: %v\n", names)
+	}
+}
@@ -0,0 +1,74 @@
+package bricklinkapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// errPreview aborts the call previewed by PreviewURL once its request is
+// recorded
+var errPreview = errors.New("request not sent: preview only")
+
+// previewRequest is a request handler recording the first request instead of
+// sending it
+type previewRequest struct {
+	method string
+	uri    string
+}
+
+func (p *previewRequest) Request(method, uri string) ([]byte, error) {
+	return p.requestContext(context.Background(), method, uri, nil)
+}
+
+func (p *previewRequest) requestContext(ctx context.Context, method, uri string, payload []byte) ([]byte, error) {
+	if p.method == "" {
+		p.method, p.uri = method, uri
+	}
+	return nil, errPreview
+}
+
+// PreviewURL returns the URL of the first request issued by call, without
+// sending it. The URL is built exactly as for the real request, including
+// validation, default params, request interceptors and query encoding, e.g.
+// for debugging or as external cache key:
+//
+//	u, err := bl.PreviewURL(func(bl *Bricklink) error {
+//		_, err := bl.GetItemPrice("PART", "3001", params)
+//		return err
+//	})
+//
+// The cache, request coalescing, retries and rate limit of the handler are
+// skipped. If call fails before issuing a request, e.g. on invalid params,
+// its error is returned.
+func (bl Bricklink) PreviewURL(call func(bl *Bricklink) error) (string, error) {
+	r, ok := bl.request.(*request)
+	if !ok {
+		r = &request{}
+	}
+
+	p := &previewRequest{}
+	preview := bl
+	preview.request = p
+	preview.cache = nil
+	preview.flight = nil
+	preview.limiter = nil
+	preview.retries = 0
+	preview.verifyWrites = false
+
+	err := call(&preview)
+	if p.method == "" {
+		if err == nil {
+			err = errors.New("no request was issued")
+		}
+		return "", err
+	}
+
+	out := r.outgoing(p.method, p.uri)
+	req, err := http.NewRequest(p.method, r.url(out.URI), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return req.URL.String(), nil
+}
@@ -0,0 +1,36 @@
+package bricklinkapi
+
+import "testing"
+
+func TestPreviewURL(t *testing.T) {
+	bl := New("", "", "", "", WithDefaultCurrency("EUR"))
+
+	testCases := []struct {
+		desc string
+		call func(bl *Bricklink) error
+		expS string
+		err  bool
+	}{
+		{desc: "testing string method", call: func(bl *Bricklink) error {
+			_, err := bl.GetItemPrice("SET", "6020", map[string]string{"guide_type": "sold"})
+			return err
+		}, expS: brickLinkAPIBaseURL + "/items/SET/6020/price?currency_code=EUR&guide_type=sold"},
+		{desc: "testing parsed method", call: func(bl *Bricklink) error {
+			_, err := bl.GetColorListParsed()
+			return err
+		}, expS: brickLinkAPIBaseURL + "/colors"},
+		{desc: "testing invalid call", call: func(bl *Bricklink) error {
+			_, err := bl.GetItem("INVALID", "3001")
+			return err
+		}, expS: "", err: true},
+	}
+	for _, tc := range testCases {
+		result, err := bl.PreviewURL(tc.call)
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if result != tc.expS {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expS, result)
+		}
+	}
+}
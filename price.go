@@ -0,0 +1,410 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PriceGuideOptions are the optional parameters of a price guide request.
+// Empty fields are not sent and BrickLink's defaults apply.
+type PriceGuideOptions struct {
+	// ColorID of the item, not sent if 0
+	ColorID int
+	// GuideType is "stock" (current listings, the default) or "sold"
+	// (sales of the last six months)
+	GuideType string
+	// NewOrUsed is "N" or "U". If unset it is defaulted by item type, see
+	// normalize
+	NewOrUsed string
+	// CountryCode restricts the guide to sellers from that country
+	CountryCode string
+	// Region restricts the guide to sellers from that region, e.g. "europe"
+	Region string
+	// CurrencyCode is the currency the prices are reported in
+	CurrencyCode string
+	// VAT is "N" (exclude, the default), "Y" (include) or "O" (Norway)
+	VAT string
+	// Completeness is "C" (complete), "B" (incomplete) or "S" (sealed). It
+	// is only valid for used sets, whose guide it defaults to "C", see
+	// normalize
+	Completeness string
+}
+
+// PriceGuide is the price statistics of an item.
+//
+// The sold guide ("guide_type=sold") always reflects the sales of the last
+// six months. The window is fixed by BrickLink and can't be changed through
+// the API, older sales are not available.
+type PriceGuide struct {
+	Item         Item   `json:"item"`
+	NewOrUsed    string `json:"new_or_used"`
+	CurrencyCode string `json:"currency_code"`
+
+	// MinPrice is the cheapest current listing for the stock guide and
+	// the cheapest sale for the sold guide, as computed by BrickLink
+	MinPrice      Money         `json:"min_price"`
+	MaxPrice      Money         `json:"max_price"`
+	AvgPrice      Money         `json:"avg_price"`
+	QtyAvgPrice   Money         `json:"qty_avg_price"`
+	UnitQuantity  int           `json:"unit_quantity"`
+	TotalQuantity int           `json:"total_quantity"`
+	PriceDetail   []PriceDetail `json:"price_detail"`
+
+	// Extras holds the fields of the response not modeled by the struct
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// PriceDetail is a single listing of the stock guide or a single sale of the
+// sold guide. The seller and buyer countries and the order date are only
+// reported for sales, ShippingAvailable only for listings.
+type PriceDetail struct {
+	Quantity          int    `json:"quantity"`
+	UnitPrice         Money  `json:"unit_price"`
+	ShippingAvailable bool   `json:"shipping_available"`
+	SellerCountryCode string `json:"seller_country_code"`
+	BuyerCountryCode  string `json:"buyer_country_code"`
+	DateOrdered       string `json:"date_ordered"`
+}
+
+// usedOnlyTypes are the item types only sold used, their price guides
+// default to "U"
+var usedOnlyTypes = []string{"UNSORTED_LOT"}
+
+// normalize returns the options with defaults applied for the item type.
+// An unset NewOrUsed becomes "U" for item types only sold used (unsorted
+// lots) and "N" for all others, matching BrickLink's own default. An unset
+// Completeness becomes "C" for used sets, so guides of used sets don't mix
+// complete and incomplete sets. Set values are never changed.
+func (o PriceGuideOptions) normalize(itemType string) PriceGuideOptions {
+	if o.NewOrUsed == "" {
+		o.NewOrUsed = "N"
+		if stringInSlice(itemType, usedOnlyTypes) {
+			o.NewOrUsed = "U"
+		}
+	}
+	if o.Completeness == "" && strings.EqualFold(itemType, "SET") && strings.EqualFold(o.NewOrUsed, "U") {
+		o.Completeness = "C"
+	}
+	return o
+}
+
+// validate checks the condition and completeness of normalized options:
+// completeness can only be requested for sets, and only for the used guide.
+func (o PriceGuideOptions) validate(itemType string) error {
+	if o.Completeness == "" {
+		return nil
+	}
+	if !strings.EqualFold(itemType, "SET") {
+		return fmt.Errorf("completeness is only valid for sets, not %v", itemType)
+	}
+	if !strings.EqualFold(o.NewOrUsed, "U") {
+		return fmt.Errorf("completeness is only valid for used sets, not new_or_used %v", o.NewOrUsed)
+	}
+	return validateParam(o.Completeness, completenessValues)
+}
+
+// Median returns the median unit price of the price details, weighted by
+// quantity: half of the items were listed or sold at this price or below.
+// It is more robust than the average for skewed markets. ok is false if the
+// guide has no details.
+func (g PriceGuide) Median() (median Money, ok bool) {
+	details := make([]PriceDetail, 0, len(g.PriceDetail))
+	total := 0
+	for _, d := range g.PriceDetail {
+		if d.Quantity > 0 {
+			details = append(details, d)
+			total += d.Quantity
+		}
+	}
+	if total == 0 {
+		return median, false
+	}
+
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].UnitPrice.Amount < details[j].UnitPrice.Amount
+	})
+
+	cumulative := 0
+	for _, d := range details {
+		cumulative += d.Quantity
+		if cumulative*2 >= total {
+			median = d.UnitPrice
+			break
+		}
+	}
+	median.Currency = g.CurrencyCode
+
+	return median, true
+}
+
+// params returns the options as query parameters
+func (o PriceGuideOptions) params() map[string]string {
+	params := make(map[string]string)
+	if o.ColorID != 0 {
+		params["color_id"] = strconv.Itoa(o.ColorID)
+	}
+	if o.GuideType != "" {
+		params["guide_type"] = o.GuideType
+	}
+	if o.NewOrUsed != "" {
+		params["new_or_used"] = o.NewOrUsed
+	}
+	if o.CountryCode != "" {
+		params["country_code"] = o.CountryCode
+	}
+	if o.Region != "" {
+		params["region"] = o.Region
+	}
+	if o.CurrencyCode != "" {
+		params["currency_code"] = o.CurrencyCode
+	}
+	if o.VAT != "" {
+		params["vat"] = o.VAT
+	}
+	if o.Completeness != "" {
+		params["completeness"] = o.Completeness
+	}
+	return params
+}
+
+// priceDefaults returns a copy of the price guide params with the defaults
+// set by WithDefaultRegion and WithDefaultCurrency added. Params present are
+// never overridden, and the default region is only added if neither region
+// nor country_code is present.
+func (bl Bricklink) priceDefaults(params map[string]string) map[string]string {
+	p := make(map[string]string, len(params)+2)
+	for k, v := range params {
+		p[k] = v
+	}
+
+	_, hasRegion := p["region"]
+	_, hasCountry := p["country_code"]
+	if bl.defaultRegion != "" && !hasRegion && !hasCountry {
+		p["region"] = bl.defaultRegion
+	}
+	if _, ok := p["currency_code"]; bl.defaultCurrency != "" && !ok {
+		p["currency_code"] = bl.defaultCurrency
+	}
+
+	return p
+}
+
+// GetPriceGuide querys for the price guide of the specified item and returns
+// it parsed. All prices carry the currency of the guide.
+//
+// The guide covers a single condition: without opts.NewOrUsed the new guide
+// is requested, except for unsorted lots, and new_or_used is always sent
+// explicitly. Use GetPriceGuideBoth for both conditions.
+func (bl Bricklink) GetPriceGuide(itemType, itemNumber string, opts PriceGuideOptions) (PriceGuide, error) {
+	return bl.priceGuide(bl.context(), itemType, itemNumber, opts)
+}
+
+// PriceGuideWithTimestamp is a price guide along with the time it was
+// fetched from BrickLink, which is earlier than the call for cached guides.
+type PriceGuideWithTimestamp struct {
+	PriceGuide
+	FetchedAt time.Time
+}
+
+// Age returns how long ago the guide was fetched.
+func (g PriceGuideWithTimestamp) Age() time.Duration {
+	return time.Since(g.FetchedAt)
+}
+
+// GetPriceGuideWithTimestamp is like GetPriceGuide but also reports when the
+// guide was fetched, so repricers can tell whether it is recent enough. See
+// WithPriceGuideMaxAge to refetch old cached guides automatically.
+func (bl Bricklink) GetPriceGuideWithTimestamp(itemType, itemNumber string, opts PriceGuideOptions) (PriceGuideWithTimestamp, error) {
+	guide, fetched, err := bl.priceGuideAt(bl.context(), itemType, itemNumber, opts)
+	return PriceGuideWithTimestamp{PriceGuide: guide, FetchedAt: fetched}, err
+}
+
+// PriceGuidePair holds the new and used price guides of an item.
+type PriceGuidePair struct {
+	New  PriceGuide
+	Used PriceGuide
+}
+
+// GetPriceGuideBoth querys for the new and the used price guide of the item
+// concurrently, two requests. opts.NewOrUsed is ignored, opts.Completeness
+// only applies to the used guide. The guides which could be fetched are
+// returned, failures as MultiError.
+func (bl Bricklink) GetPriceGuideBoth(itemType, itemNumber string, opts PriceGuideOptions) (PriceGuidePair, error) {
+	newOpts, usedOpts := opts, opts
+	newOpts.NewOrUsed, newOpts.Completeness = "N", ""
+	usedOpts.NewOrUsed = "U"
+
+	var pair PriceGuidePair
+	errs, _ := runBatch(bl.context(), 2, bl.batchOptions(nil), func(ctx context.Context, i int) (err error) {
+		if i == 0 {
+			pair.New, err = bl.priceGuide(ctx, itemType, itemNumber, newOpts)
+			return err
+		}
+		pair.Used, err = bl.priceGuide(ctx, itemType, itemNumber, usedOpts)
+		return err
+	})
+
+	var merr MultiError
+	for i, err := range errs {
+		if err != nil {
+			merr = append(merr, fmt.Errorf("%v guide: %w", []string{"new", "used"}[i], err))
+		}
+	}
+
+	return pair, merr.errOrNil()
+}
+
+// GetPriceGuideDetail querys for the price guide of an item and returns the
+// individual listings or sales instead of the aggregates, e.g. to analyze the
+// price distribution. The result is never nil.
+func (bl Bricklink) GetPriceGuideDetail(itemType, itemNumber string, opts PriceGuideOptions) ([]PriceDetail, error) {
+	guide, err := bl.priceGuide(bl.context(), itemType, itemNumber, opts)
+	if err != nil {
+		return []PriceDetail{}, err
+	}
+	if guide.PriceDetail == nil {
+		return []PriceDetail{}, nil
+	}
+
+	return guide.PriceDetail, nil
+}
+
+func (bl Bricklink) priceGuide(ctx context.Context, itemType, itemNumber string, opts PriceGuideOptions) (guide PriceGuide, err error) {
+	guide, _, err = bl.priceGuideAt(ctx, itemType, itemNumber, opts)
+	return guide, err
+}
+
+// priceGuideAt is like priceGuide but also returns when the guide was
+// fetched. Cached guides older than the age set with WithPriceGuideMaxAge
+// are fetched again.
+func (bl Bricklink) priceGuideAt(ctx context.Context, itemType, itemNumber string, opts PriceGuideOptions) (guide PriceGuide, fetched time.Time, err error) {
+	// validate itemType
+	err = bl.validParam(itemType, itemTypes)
+	if err != nil {
+		return guide, fetched, err
+	}
+
+	// validate itemNumber
+	if itemNumber == "" {
+		return guide, fetched, errors.New("itemNumber is not specified")
+	}
+	itemNumber = bl.itemNumber(itemType, itemNumber)
+
+	// validate and build params
+	opts = opts.normalize(itemType)
+	if !bl.skipValidation {
+		err = opts.validate(itemType)
+		if err != nil {
+			return guide, fetched, err
+		}
+	}
+	params := bl.priceDefaults(opts.params())
+	if c, ok := params["currency_code"]; ok && !bl.skipValidation {
+		params["currency_code"], err = NormalizeCurrency(c)
+		if err != nil {
+			return guide, fetched, err
+		}
+	}
+
+	// build uri
+	uri := buildURI("/items/"+itemType+"/"+itemNumber+"/price", params)
+
+	fetched, err = bl.getParsedAt(ctx, uri, &guide, bl.priceGuideMaxAge)
+	if err != nil {
+		return guide, fetched, err
+	}
+
+	// attach the currency to all prices
+	guide.MinPrice.Currency = guide.CurrencyCode
+	guide.MaxPrice.Currency = guide.CurrencyCode
+	guide.AvgPrice.Currency = guide.CurrencyCode
+	guide.QtyAvgPrice.Currency = guide.CurrencyCode
+	for i := range guide.PriceDetail {
+		guide.PriceDetail[i].UnitPrice.Currency = guide.CurrencyCode
+	}
+
+	return guide, fetched, nil
+}
+
+// PricedItem is a catalog item combined with its price guide.
+type PricedItem struct {
+	Item       CatalogItem
+	PriceGuide PriceGuide
+
+	// HasPriceGuide is false if the price guide could not be fetched, the
+	// reason is kept in PriceGuideErr
+	HasPriceGuide bool
+	PriceGuideErr error
+}
+
+// GetPricedItem fetches the catalog item and its price guide with two
+// concurrent requests, both subject to the rate limiter. If only the price
+// guide fails, the item is returned with HasPriceGuide unset.
+func (bl Bricklink) GetPricedItem(ctx context.Context, itemType, itemNumber string, opts PriceGuideOptions) (priced PricedItem, err error) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		priced.PriceGuide, priced.PriceGuideErr = bl.priceGuide(ctx, itemType, itemNumber, opts)
+	}()
+
+	priced.Item, err = bl.catalogItem(ctx, itemType, itemNumber)
+	wg.Wait()
+	if err != nil {
+		return priced, err
+	}
+
+	priced.HasPriceGuide = priced.PriceGuideErr == nil
+
+	return priced, nil
+}
+
+var (
+	guideTypes = []string{"stock", "sold"}
+	conditions = []string{"N", "U"}
+)
+
+// PriceGuideCombination is a valid combination of price guide parameters.
+type PriceGuideCombination struct {
+	GuideType string
+	NewOrUsed string
+}
+
+// PriceGuideCombinations returns the guide type and condition combinations
+// which are meaningful for the item type, e.g. to disable impossible options
+// in a UI. Both guide types are available for all item types; unsorted lots
+// are only sold used.
+func PriceGuideCombinations(itemType string) ([]PriceGuideCombination, error) {
+	return Bricklink{}.PriceGuideCombinations(itemType)
+}
+
+// PriceGuideCombinations is like the function PriceGuideCombinations, but
+// the item type is not checked if validation is skipped, see
+// WithSkipValidation.
+func (bl Bricklink) PriceGuideCombinations(itemType string) ([]PriceGuideCombination, error) {
+	err := bl.validParam(itemType, itemTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	conds := conditions
+	if stringInSlice(itemType, usedOnlyTypes) {
+		conds = []string{"U"}
+	}
+
+	var combinations []PriceGuideCombination
+	for _, g := range guideTypes {
+		for _, c := range conds {
+			combinations = append(combinations, PriceGuideCombination{GuideType: g, NewOrUsed: c})
+		}
+	}
+
+	return combinations, nil
+}
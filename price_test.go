@@ -0,0 +1,164 @@
+package bricklinkapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriceGuideOptionsNormalize(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		itemType string
+		opts     PriceGuideOptions
+		exp      string
+	}{
+		{desc: "testing default for parts", itemType: "PART", exp: "N"},
+		{desc: "testing default for unsorted lots", itemType: "unsorted_lot", exp: "U"},
+		{desc: "testing explicit value", itemType: "UNSORTED_LOT", opts: PriceGuideOptions{NewOrUsed: "N"}, exp: "N"},
+	}
+	for _, tc := range testCases {
+		result := tc.opts.normalize(tc.itemType).NewOrUsed
+		if result != tc.exp {
+			t.Errorf("\n%v, want: %v, got: %v\n", tc.desc, tc.exp, result)
+		}
+	}
+}
+
+func TestPriceGuideMedian(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		details []PriceDetail
+		exp     int64
+		ok      bool
+	}{
+		{desc: "testing empty details", details: nil, ok: false},
+		{desc: "testing single listing",
+			details: []PriceDetail{{Quantity: 3, UnitPrice: Money{Amount: 100}}},
+			exp:     100, ok: true},
+		{desc: "testing weighted by quantity",
+			details: []PriceDetail{
+				{Quantity: 1, UnitPrice: Money{Amount: 9000}},
+				{Quantity: 10, UnitPrice: Money{Amount: 100}},
+				{Quantity: 2, UnitPrice: Money{Amount: 500}},
+			},
+			exp: 100, ok: true},
+		{desc: "testing upper half",
+			details: []PriceDetail{
+				{Quantity: 1, UnitPrice: Money{Amount: 100}},
+				{Quantity: 5, UnitPrice: Money{Amount: 300}},
+			},
+			exp: 300, ok: true},
+	}
+	for _, tc := range testCases {
+		g := PriceGuide{CurrencyCode: "EUR", PriceDetail: tc.details}
+		m, ok := g.Median()
+		if ok != tc.ok || m.Amount != tc.exp {
+			t.Errorf("\n%v, want: %v (%v), got: %v (%v)\n", tc.desc, tc.exp, tc.ok, m.Amount, ok)
+		}
+		if ok && m.Currency != "EUR" {
+			t.Errorf("\n%v, currency not set\n", tc.desc)
+		}
+	}
+}
+
+func TestPriceDefaults(t *testing.T) {
+	bl := New("", "", "", "", WithDefaultRegion("europe"), WithDefaultCurrency("EUR"))
+
+	p := bl.priceDefaults(nil)
+	if p["region"] != "europe" || p["currency_code"] != "EUR" {
+		t.Errorf("\ndefaults not applied, got: %v\n", p)
+	}
+
+	p = bl.priceDefaults(map[string]string{"country_code": "DE", "currency_code": "USD"})
+	if _, ok := p["region"]; ok || p["currency_code"] != "USD" {
+		t.Errorf("\nper call params must win, got: %v\n", p)
+	}
+}
+
+func TestGetPriceGuideDetail(t *testing.T) {
+	f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"currency_code":"EUR","price_detail":[{"quantity":2,"unit_price":"0.1000","seller_country_code":"DE"},{"quantity":5,"unit_price":"0.2500","seller_country_code":"NL"}]}}`)}
+	bl := New("", "", "", "")
+	bl.request = f
+
+	details, err := bl.GetPriceGuideDetail("PART", "3001", PriceGuideOptions{GuideType: "sold"})
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	if len(details) != 2 || details[1].Quantity != 5 || details[1].SellerCountryCode != "NL" || details[1].UnitPrice != (Money{Amount: 2500, Currency: "EUR"}) {
+		t.Errorf("\nunexpected details: %+v\n", details)
+	}
+}
+
+func TestPriceGuideOptionsCompleteness(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		itemType string
+		opts     PriceGuideOptions
+		exp      string
+		err      bool
+	}{
+		{desc: "testing default for used sets", itemType: "SET", opts: PriceGuideOptions{NewOrUsed: "U"}, exp: "C"},
+		{desc: "testing explicit value", itemType: "SET", opts: PriceGuideOptions{NewOrUsed: "U", Completeness: "B"}, exp: "B"},
+		{desc: "testing no default for new sets", itemType: "SET", exp: ""},
+		{desc: "testing new sets", itemType: "SET", opts: PriceGuideOptions{Completeness: "S"}, err: true},
+		{desc: "testing parts", itemType: "PART", opts: PriceGuideOptions{NewOrUsed: "U", Completeness: "C"}, err: true},
+		{desc: "testing invalid value", itemType: "SET", opts: PriceGuideOptions{NewOrUsed: "U", Completeness: "X"}, err: true},
+	}
+	for _, tc := range testCases {
+		opts := tc.opts.normalize(tc.itemType)
+		err := opts.validate(tc.itemType)
+		if (err != nil) != tc.err {
+			t.Errorf("\n%v, unexpected error: %v\n", tc.desc, err)
+			continue
+		}
+		if !tc.err && opts.params()["completeness"] != tc.exp {
+			t.Errorf("\n%v, want: %v, got: %v\n", tc.desc, tc.exp, opts.params()["completeness"])
+		}
+	}
+}
+
+func TestGetPriceGuideBoth(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/items/SET/6020-1/price?new_or_used=N":                `{"meta":{"code":200},"data":{"new_or_used":"N","avg_price":"20.0000"}}`,
+		"/items/SET/6020-1/price?completeness=C&new_or_used=U": `{"meta":{"code":200},"data":{"new_or_used":"U","avg_price":"12.0000"}}`,
+	}
+
+	pair, err := bl.GetPriceGuideBoth("SET", "6020-1", PriceGuideOptions{NewOrUsed: "U"})
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if pair.New.NewOrUsed != "N" || pair.New.AvgPrice.Amount != 200000 || pair.Used.NewOrUsed != "U" || pair.Used.AvgPrice.Amount != 120000 {
+		t.Errorf("\nunexpected guides: %+v\n", pair)
+	}
+
+	_, err = bl.GetPriceGuideBoth("SET", "6020-1", PriceGuideOptions{Completeness: "B"})
+	if merr, ok := err.(MultiError); !ok || len(merr) != 1 {
+		t.Errorf("\nwant: one error for the used guide, got: %v\n", err)
+	}
+}
+
+func TestGetPriceGuideWithTimestamp(t *testing.T) {
+	for _, maxAge := range []time.Duration{0, time.Nanosecond} {
+		f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"currency_code":"USD","avg_price":"0.2500"}}`)}
+		bl := New("", "", "", "", WithCache(time.Hour), WithPriceGuideMaxAge(maxAge))
+		bl.request = f
+
+		first, err := bl.GetPriceGuideWithTimestamp("PART", "3001", PriceGuideOptions{})
+		if err != nil || first.FetchedAt.IsZero() || first.Age() < 0 {
+			t.Fatalf("\nunexpected result: %+v (%v)\n", first, err)
+		}
+		second, _ := bl.GetPriceGuideWithTimestamp("PART", "3001", PriceGuideOptions{})
+
+		want := 1
+		if maxAge > 0 {
+			want = 2
+		}
+		if f.calls != want {
+			t.Errorf("\nmax age %v: want: %v requests, got: %v\n", maxAge, want, f.calls)
+		}
+		if maxAge == 0 && !second.FetchedAt.Equal(first.FetchedAt) {
+			t.Errorf("\ncached guide should keep its fetch time, got: %v, %v\n", first.FetchedAt, second.FetchedAt)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package bricklinkapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority is the priority of a request waiting for the rate limit.
+type Priority int
+
+// Request priorities, PriorityNormal is the default
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context which sets the priority of the requests
+// issued with it. While requests of a higher priority wait for the rate limit
+// set with WithRateLimit, requests of a lower priority don't get a token,
+// e.g. so user initiated lookups overtake a background sync. Without a rate
+// limit priorities have no effect.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// helper function to get the priority of the requests issued with ctx
+func priorityOf(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
+
+// rateLimiter is a token bucket shared by all requests of a handler. It
+// holds up to burst tokens which are refilled at rate tokens per second.
+// Waiting requests of a higher priority are served first.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// waiting counts the waiting requests by priority
+	waiting map[Priority]int
+}
+
+// newRateLimiter returns a limiter of rate tokens per second, or nil for no
+// limit if rate isn't positive
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if !(rate > 0) {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+		waiting: make(map[Priority]int),
+	}
+}
+
+// wait blocks until a token is available for a request of priority p or
+// ctx is done
+func (l *rateLimiter) wait(ctx context.Context, p Priority) error {
+	waiting := false
+	for {
+		d := l.reserve(p, &waiting)
+		if d == 0 {
+			return nil
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			l.mu.Lock()
+			l.waiting[p]--
+			l.mu.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token and returns 0, or returns how long to wait until
+// the next token is available. A token is only taken if no request of a
+// higher priority is waiting. waiting tracks whether the request is counted
+// as waiting, l.waiting is updated accordingly.
+func (l *rateLimiter) reserve(p Priority, waiting *bool) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 && !l.higherWaiting(p) {
+		l.tokens--
+		if *waiting {
+			l.waiting[p]--
+			*waiting = false
+		}
+		return 0
+	}
+
+	if !*waiting {
+		l.waiting[p]++
+		*waiting = true
+	}
+
+	// with a token left for a higher priority, wait for the next one
+	missing := 1 - l.tokens
+	if missing <= 0 {
+		missing = 1
+	}
+	return time.Duration(missing / l.rate * float64(time.Second))
+}
+
+// higherWaiting reports whether a request of a higher priority than p is
+// waiting, l.mu must be held
+func (l *rateLimiter) higherWaiting(p Priority) bool {
+	for q, n := range l.waiting {
+		if q > p && n > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package bricklinkapi
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// helper function to block until n requests of priority p wait for l
+func awaitWaiting(l *rateLimiter, p Priority, n int) {
+	for {
+		l.mu.Lock()
+		waiting := l.waiting[p]
+		l.mu.Unlock()
+		if waiting >= n {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestRateLimiterPriority(t *testing.T) {
+	l := newRateLimiter(10, 1)
+	l.wait(context.Background(), PriorityNormal)
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	acquire := func(p Priority) {
+		defer wg.Done()
+		l.wait(context.Background(), p)
+		mu.Lock()
+		order = append(order, p)
+		mu.Unlock()
+	}
+
+	wg.Add(2)
+	go acquire(PriorityLow)
+	awaitWaiting(l, PriorityLow, 1)
+	go acquire(PriorityHigh)
+	awaitWaiting(l, PriorityHigh, 1)
+	wg.Wait()
+
+	if len(order) != 2 || order[0] != PriorityHigh {
+		t.Errorf("\nhigh priority request should be served first, got: %v\n", order)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := newRateLimiter(0.001, 1)
+	l.wait(context.Background(), PriorityNormal)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := l.wait(ctx, PriorityHigh)
+	if err == nil {
+		t.Errorf("\nwant error, got nil\n")
+	}
+	if l.higherWaiting(PriorityNormal) {
+		t.Errorf("\ncancelled request should not be counted as waiting\n")
+	}
+}
+
+func TestRateLimitNonPositive(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		bl := New("", "", "", "", WithRateLimit(rate, 1))
+		if bl.limiter != nil {
+			t.Errorf("\nrate %v: want no limit, got: %+v\n", rate, bl.limiter)
+		}
+
+		bl.request = &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[]}`)}
+		for i := 0; i < 3; i++ {
+			_, err := bl.GetColorListParsed()
+			if err != nil {
+				t.Errorf("\nrate %v: unexpected error: %v\n", rate, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// ReferenceData is a snapshot of the catalog colors and categories with
+// lookups by ID and name, for validation and rendering without requests. It
+// is immutable and safe for concurrent use: all colors and categories are
+// returned as copies, including their Extras.
+type ReferenceData struct {
+	colors     []Color
+	categories []Category
+
+	colorsByID       map[int]int
+	colorsByName     map[string]int
+	categoriesByID   map[int]int
+	categoriesByName map[string]int
+}
+
+// LoadReferenceData fetches the color and category lists concurrently and
+// returns them as a snapshot. The lists are served from the cache if caching
+// is enabled.
+func (bl Bricklink) LoadReferenceData(ctx context.Context) (*ReferenceData, error) {
+	colors, categories, err := bl.referenceLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newReferenceData(colors, categories), nil
+}
+
+func newReferenceData(colors []Color, categories []Category) *ReferenceData {
+	r := &ReferenceData{
+		colors:           colors,
+		categories:       categories,
+		colorsByID:       make(map[int]int, len(colors)),
+		colorsByName:     make(map[string]int, len(colors)),
+		categoriesByID:   make(map[int]int, len(categories)),
+		categoriesByName: make(map[string]int, len(categories)),
+	}
+	for i, c := range colors {
+		r.colorsByID[c.ColorID] = i
+		r.colorsByName[strings.ToLower(c.ColorName)] = i
+	}
+	for i, c := range categories {
+		r.categoriesByID[c.CategoryID] = i
+		r.categoriesByName[strings.ToLower(c.CategoryName)] = i
+	}
+	return r
+}
+
+// Colors returns a copy of all colors.
+func (r *ReferenceData) Colors() []Color {
+	colors := make([]Color, len(r.colors))
+	for i := range r.colors {
+		colors[i] = r.color(i)
+	}
+	return colors
+}
+
+// Categories returns a copy of all categories.
+func (r *ReferenceData) Categories() []Category {
+	categories := make([]Category, len(r.categories))
+	for i := range r.categories {
+		categories[i] = r.category(i)
+	}
+	return categories
+}
+
+// color returns a copy of the color at index i
+func (r *ReferenceData) color(i int) Color {
+	c := r.colors[i]
+	c.Extras = copyExtras(c.Extras)
+	return c
+}
+
+// category returns a copy of the category at index i
+func (r *ReferenceData) category(i int) Category {
+	c := r.categories[i]
+	c.Extras = copyExtras(c.Extras)
+	return c
+}
+
+// helper function to deep copy the Extras of a color or category
+func copyExtras(extras map[string]json.RawMessage) map[string]json.RawMessage {
+	if extras == nil {
+		return nil
+	}
+	c := make(map[string]json.RawMessage, len(extras))
+	for k, v := range extras {
+		c[k] = append(json.RawMessage(nil), v...)
+	}
+	return c
+}
+
+// Color returns the color with the ID.
+func (r *ReferenceData) Color(colorID int) (Color, bool) {
+	i, ok := r.colorsByID[colorID]
+	if !ok {
+		return Color{}, false
+	}
+	return r.color(i), true
+}
+
+// ColorByName returns the color with the name, ignoring case.
+func (r *ReferenceData) ColorByName(name string) (Color, bool) {
+	i, ok := r.colorsByName[strings.ToLower(name)]
+	if !ok {
+		return Color{}, false
+	}
+	return r.color(i), true
+}
+
+// Category returns the category with the ID.
+func (r *ReferenceData) Category(categoryID int) (Category, bool) {
+	i, ok := r.categoriesByID[categoryID]
+	if !ok {
+		return Category{}, false
+	}
+	return r.category(i), true
+}
+
+// CategoryByName returns the category with the name, ignoring case.
+func (r *ReferenceData) CategoryByName(name string) (Category, bool) {
+	i, ok := r.categoriesByName[strings.ToLower(name)]
+	if !ok {
+		return Category{}, false
+	}
+	return r.category(i), true
+}
+
+// ItemTypes returns all valid item types, see ItemTypes.
+func (r *ReferenceData) ItemTypes() []string {
+	return ItemTypes()
+}
@@ -0,0 +1,50 @@
+package bricklinkapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLoadReferenceData(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/colors":     `{"meta":{"code":200},"data":[{"color_id":1,"color_name":"White","rgb":"FFFFFF"},{"color_id":5,"color_name":"Red"}]}`,
+		"/categories": `{"meta":{"code":200},"data":[{"category_id":5,"category_name":"Brick"}]}`,
+	}
+
+	ref, err := bl.LoadReferenceData(context.Background())
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+
+	if c, ok := ref.Color(5); !ok || c.ColorName != "Red" {
+		t.Errorf("\nwant: Red, got: %+v\n", c)
+	}
+	if c, ok := ref.ColorByName("white"); !ok || c.ColorID != 1 {
+		t.Errorf("\nwant: 1, got: %+v\n", c)
+	}
+	if _, ok := ref.Color(9); ok {
+		t.Errorf("\nunexpected color 9\n")
+	}
+	if c, ok := ref.CategoryByName("BRICK"); !ok || c.CategoryID != 5 {
+		t.Errorf("\nwant: 5, got: %+v\n", c)
+	}
+	if _, ok := ref.Category(5); !ok {
+		t.Errorf("\nmissing category 5\n")
+	}
+
+	colors := ref.Colors()
+	colors[0].ColorName = "changed"
+	colors[0].Extras["rgb"][0] = '0'
+	c, _ := ref.Color(1)
+	c.Extras["rgb"] = nil
+	if c, _ := ref.Color(1); c.ColorName != "White" || string(c.Extras["rgb"]) != `"FFFFFF"` {
+		t.Errorf("\nsnapshot was modified: %+v\n", c)
+	}
+
+	bl.request = routeRequest{}
+	_, err = bl.LoadReferenceData(context.Background())
+	if err == nil {
+		t.Errorf("\nexpected error\n")
+	}
+}
@@ -0,0 +1,171 @@
+package bricklinkapi
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RepriceOption configures RepriceFromCSV.
+type RepriceOption func(*repriceOptions)
+
+type repriceOptions struct {
+	idColumn    int
+	priceColumn int
+
+	// header names the columns of the header row, if there is one
+	header            bool
+	idName, priceName string
+}
+
+// RepriceColumns sets the zero based indexes of the inventory ID and price
+// columns, by default 0 and 1. Negative indexes make RepriceFromCSV fail.
+func RepriceColumns(idColumn, priceColumn int) RepriceOption {
+	return func(o *repriceOptions) {
+		o.idColumn = idColumn
+		o.priceColumn = priceColumn
+		o.header = false
+	}
+}
+
+// RepriceHeader makes RepriceFromCSV read the first row as header and take
+// the inventory IDs and prices from the columns with the given names,
+// compared ignoring case.
+func RepriceHeader(idColumn, priceColumn string) RepriceOption {
+	return func(o *repriceOptions) {
+		o.header = true
+		o.idName = idColumn
+		o.priceName = priceColumn
+	}
+}
+
+// reprice is a validated row of a reprice CSV
+type reprice struct {
+	line        int
+	inventoryID int
+	price       Money
+}
+
+// RepriceFromCSV sets the unit prices of the lots listed in the CSV, one
+// inventory ID and price in the store currency per row, e.g. "1234,0.25". All
+// rows are validated first; the valid rows are updated concurrently, subject
+// to the rate limit of the handler. It returns the number of lots updated,
+// the errors of invalid rows and failed updates are returned by line number
+// as MultiError.
+func (bl Bricklink) RepriceFromCSV(ctx context.Context, r io.Reader, opts ...RepriceOption) (int, error) {
+	o := repriceOptions{idColumn: 0, priceColumn: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.idColumn < 0 || o.priceColumn < 0 {
+		return 0, fmt.Errorf("invalid column indexes %v and %v", o.idColumn, o.priceColumn)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var merr MultiError
+	var rows []reprice
+	seen := make(map[int]int)
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				return 0, fmt.Errorf("line %v: %v", perr.Line, perr.Err)
+			}
+			return 0, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		if first && o.header {
+			o.idColumn, o.priceColumn, err = repriceHeader(record, o.idName, o.priceName)
+			if err != nil {
+				return 0, fmt.Errorf("line %v: %v", line, err)
+			}
+			continue
+		}
+
+		row, err := parseReprice(record, o.idColumn, o.priceColumn)
+		if err == nil && seen[row.inventoryID] != 0 {
+			err = fmt.Errorf("inventory %v is already repriced in line %v", row.inventoryID, seen[row.inventoryID])
+		}
+		if err != nil {
+			merr = append(merr, fmt.Errorf("line %v: %v", line, err))
+			continue
+		}
+		row.line = line
+		seen[row.inventoryID] = line
+		rows = append(rows, row)
+	}
+
+	updated := make([]bool, len(rows))
+	errs, _ := runBatch(ctx, len(rows), bl.batchOptions(nil), func(ctx context.Context, i int) error {
+		price := rows[i].price
+		_, err := bl.UpdateInventory(ctx, InventoryUpdate{InventoryID: rows[i].inventoryID, UnitPrice: &price})
+		updated[i] = err == nil
+		return err
+	})
+
+	count := 0
+	for i, err := range errs {
+		if err != nil {
+			merr = append(merr, fmt.Errorf("line %v: inventory %v: %v", rows[i].line, rows[i].inventoryID, err))
+		}
+		if updated[i] {
+			count++
+		}
+	}
+
+	return count, merr.errOrNil()
+}
+
+// helper function to find the columns of a reprice CSV by name
+func repriceHeader(record []string, idName, priceName string) (idColumn, priceColumn int, err error) {
+	idColumn, priceColumn = -1, -1
+	for i, name := range record {
+		switch {
+		case strings.EqualFold(strings.TrimSpace(name), idName):
+			idColumn = i
+		case strings.EqualFold(strings.TrimSpace(name), priceName):
+			priceColumn = i
+		}
+	}
+	if idColumn < 0 || priceColumn < 0 {
+		return 0, 0, fmt.Errorf("header lacks column %q or %q", idName, priceName)
+	}
+	return idColumn, priceColumn, nil
+}
+
+// helper function to parse and validate a row of a reprice CSV
+func parseReprice(record []string, idColumn, priceColumn int) (row reprice, err error) {
+	if idColumn < 0 || priceColumn < 0 {
+		return row, errors.New("invalid column indexes")
+	}
+	if idColumn >= len(record) || priceColumn >= len(record) {
+		return row, errors.New("missing columns")
+	}
+
+	row.inventoryID, err = strconv.Atoi(strings.TrimSpace(record[idColumn]))
+	if err != nil || row.inventoryID <= 0 {
+		return row, fmt.Errorf("invalid inventory ID %q", record[idColumn])
+	}
+
+	row.price, err = ParseMoney(record[priceColumn], "")
+	if err != nil {
+		return row, err
+	}
+	if row.price.Amount < 0 {
+		return row, fmt.Errorf("price %v is negative", row.price)
+	}
+
+	return row, nil
+}
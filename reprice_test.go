@@ -0,0 +1,63 @@
+package bricklinkapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRepriceFromCSV(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		csv     string
+		opts    []RepriceOption
+		updated int
+		errs    []string
+	}{
+		{desc: "testing plain", csv: "1,0.25\n2,1.5\n", updated: 2},
+		{desc: "testing header", csv: "price,lot\n0.25,1\n1.5,2\n", opts: []RepriceOption{RepriceHeader("Lot", "Price")}, updated: 2},
+		{desc: "testing columns", csv: "x,1,0.25\n", opts: []RepriceOption{RepriceColumns(1, 2)}, updated: 1},
+		{desc: "testing invalid rows", csv: "1,0.25\nabc,1\n3,-1\n1,2\n4\n", updated: 1,
+			errs: []string{"line 2:", "line 3:", "line 4: inventory 1 is already repriced in line 1", "line 5:"}},
+		{desc: "testing failed update", csv: "1,0.25\n9,1\n", updated: 1, errs: []string{"line 2: inventory 9:"}},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "")
+		bl.request = routeRequest{
+			"/inventories/1": `{"meta":{"code":200},"data":{"inventory_id":1}}`,
+			"/inventories/2": `{"meta":{"code":200},"data":{"inventory_id":2}}`,
+			"/inventories/9": `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`,
+		}
+
+		n, err := bl.RepriceFromCSV(context.Background(), strings.NewReader(tc.csv), tc.opts...)
+		if n != tc.updated {
+			t.Errorf("%v, want: %v updated, got: %v\n", tc.desc, tc.updated, n)
+		}
+		merr, _ := err.(MultiError)
+		if len(merr) != len(tc.errs) {
+			t.Errorf("%v, want: %v errors, got: %v\n", tc.desc, len(tc.errs), err)
+			continue
+		}
+		for i, exp := range tc.errs {
+			if !strings.HasPrefix(merr[i].Error(), exp) {
+				t.Errorf("%v, want prefix: %v, got: %v\n", tc.desc, exp, merr[i])
+			}
+		}
+	}
+
+	bl := New("", "", "", "")
+	_, err := bl.RepriceFromCSV(context.Background(), strings.NewReader("id,cost\n"), RepriceHeader("id", "price"))
+	if err == nil {
+		t.Errorf("\nexpected error for a missing column\n")
+	}
+
+	_, err = bl.RepriceFromCSV(context.Background(), strings.NewReader("1,0.25\na\"b,3\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("\nwant: parse error in line 2, got: %v\n", err)
+	}
+
+	_, err = bl.RepriceFromCSV(context.Background(), strings.NewReader("1,0.25\n"), RepriceColumns(-1, 1))
+	if err == nil {
+		t.Errorf("\nexpected error for a negative column\n")
+	}
+}
@@ -1,13 +1,16 @@
 package bricklinkapi
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha1"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"math/rand"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -24,87 +27,206 @@ type request struct {
 	consumerSecret string
 	token          string
 	tokenSecret    string
+	language       string
+	interceptors   []func(*OutgoingRequest)
+
+	// baseURL overrides brickLinkAPIBaseURL if set
+	baseURL string
+
+	// signatureLogger logs the signature base strings if set
+	signatureLogger Logger
+}
+
+// OutgoingRequest is the request about to be sent, as passed to the
+// interceptors set with WithRequestInterceptor. The interceptors run before
+// the request is signed, so changes to URI are covered by the OAuth
+// signature. The Authorization header is set after the interceptors and
+// can't be changed.
+type OutgoingRequest struct {
+	// Method is the HTTP method, it is informational only
+	Method string
+	// URI is the path and query relative to the API base URL, e.g.
+	// "/items/PART/3001"
+	URI string
+	// Header holds the headers sent with the request
+	Header map[string]string
+}
+
+// contextRequestHandler is implemented by request handlers which can be
+// bound to a context.
+type contextRequestHandler interface {
+	requestContext(ctx context.Context, method, uri string, payload []byte) (body []byte, err error)
+}
+
+// transportError marks errors of the network round trip. The request may or
+// may not have reached BrickLink, so these are the errors worth retrying.
+type transportError struct {
+	err error
 }
 
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// errEmptyBody is returned by the request handler along with an empty GET
+// response body of unknown length, which is most likely cut off
+var errEmptyBody = errors.New("response body is empty")
+
 // request() handles the request process. It builds of the oauth header,
 // sets the request parameters and issues the request.
 // The response body is returned as a []byte slice.
 func (r request) Request(method, uri string) (body []byte, err error) {
-	// new client
-	client := http.Client{
-		Timeout: time.Second * 30, // Maximum of 5 secs
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	body, err = r.requestContext(ctx, method, uri, nil)
+	if err == errEmptyBody {
+		err = nil
 	}
+	return body, err
+}
+
+// requestContext is like Request but the request is bound to ctx, which
+// also sets its timeout. A non nil payload is sent as JSON request body.
+func (r request) requestContext(ctx context.Context, method, uri string, payload []byte) (body []byte, err error) {
+	// new client
+	client := http.Client{}
+
+	// build the outgoing request and let the interceptors modify it
+	out := r.outgoing(method, uri)
 
 	// build new request
-	req, err := http.NewRequest(method, brickLinkAPIBaseURL+uri, nil)
+	req, err := http.NewRequestWithContext(ctx, method, r.url(out.URI), bytes.NewReader(payload))
 	if err != nil {
 		return body, fmt.Errorf("could not build new request: %v", err)
 	}
-
-	// construct timestamp and nonce used in the oauth
-	timeUnix := time.Now().Unix()
-	timestamp := strconv.FormatInt(timeUnix, 10)
-	nonce := strconv.FormatInt(rand.New(rand.NewSource(timeUnix)).Int63(), 10)
-
-	// construct values for oauth params
-	var oauthParams []string
-	oauthParams = append(oauthParams, "oauth_consumer_key="+r.consumerKey)
-	oauthParams = append(oauthParams, "oauth_token="+r.token)
-	oauthParams = append(oauthParams, "oauth_signature_method="+oauthSignatureMethod)
-	oauthParams = append(oauthParams, "oauth_timestamp="+timestamp)
-	oauthParams = append(oauthParams, "oauth_nonce="+nonce)
-	oauthParams = append(oauthParams, "oauth_version="+oauthVersion)
-
-	// extract uri params from URI and add to oauth params map
-	uriSplit := strings.Split(req.URL.String(), "?")
-	if len(uriSplit) > 1 {
-		uriParamString := strings.Split(uriSplit[1], "&")
-		for _, s := range uriParamString {
-			oauthParams = append(oauthParams, s)
-		}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// generate signature
-	baseURL := generateBaseURL(req, oauthParams)
-	signature := generateSignature(baseURL, r.consumerSecret, r.tokenSecret)
-
 	// set header
-	req.Header.Set("User-Agent", "bricklinkapi-test")
-
-	// build authorization string for the header
-	authorization := "OAuth "
-	authorization += "oauth_consumer_key=\"" + r.consumerKey + "\","
-	authorization += "oauth_token=\"" + r.token + "\","
-	authorization += "oauth_signature_method=\"" + oauthSignatureMethod + "\","
-	authorization += "oauth_signature=\"" + signature + "\","
-	authorization += "oauth_timestamp=\"" + timestamp + "\","
-	authorization += "oauth_nonce=\"" + nonce + "\","
-	authorization += "oauth_version=\"" + oauthVersion + "\""
+	for k, v := range out.Header {
+		req.Header.Set(k, v)
+	}
 
+	// sign the request
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := newNonce()
+	authorization, _, err := signRequest(r.creds(), method, req.URL.String(), nil, timestamp, nonce)
+	if err != nil {
+		return body, err
+	}
+	if r.signatureLogger != nil {
+		// sign again with the credentials redacted, the base string only
+		// differs in them
+		_, base, _ := signRequest(Creds{ConsumerKey: redacted, Token: redacted}, method, req.URL.String(), nil, timestamp, nonce)
+		r.signatureLogger.Printf("bricklinkapi: signature base string of %v %v: %v", method, out.URI, base)
+	}
 	req.Header.Set("Authorization", authorization)
 
 	// start request
 	resp, err := client.Do(req)
 	if err != nil {
-		return body, err
+		return body, &transportError{err}
 	}
+	defer resp.Body.Close()
 
 	// read response body
 	body, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return body, err
+		return body, &transportError{err}
+	}
+
+	// an empty body of unknown length is most likely cut off, while an
+	// empty body declared with a Content-Length of 0 is intended. Whether
+	// it's worth another attempt is up to the retry configuration.
+	if method == "GET" && len(body) == 0 && resp.ContentLength < 0 {
+		return body, errEmptyBody
+	}
+
+	// maintenance pages are served as HTML, even with a 200 status
+	if strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		return body, serviceUnavailable(body)
 	}
 
 	return body, nil
 }
 
-// generateBaseURL generates the base URL for the signature
+// creds returns the credentials the requests are signed with
+func (r request) creds() Creds {
+	return Creds{
+		ConsumerKey:    r.consumerKey,
+		ConsumerSecret: r.consumerSecret,
+		Token:          r.token,
+		TokenSecret:    r.tokenSecret,
+	}
+}
+
+// outgoing builds the outgoing request with the default headers and applies
+// the interceptors to it
+func (r request) outgoing(method, uri string) *OutgoingRequest {
+	out := &OutgoingRequest{
+		Method: method,
+		URI:    uri,
+		Header: map[string]string{"User-Agent": "bricklinkapi-test"},
+	}
+	if r.language != "" {
+		out.Header["Accept-Language"] = r.language
+	}
+	for _, intercept := range r.interceptors {
+		intercept(out)
+	}
+	return out
+}
+
+// url returns the absolute URL of uri
+func (r request) url(uri string) string {
+	if r.baseURL != "" {
+		return r.baseURL + uri
+	}
+	return brickLinkAPIBaseURL + uri
+}
+
+// joinBaseURL joins the host, the base path and the API path to the base URL
+// of the requests. Empty segments are dropped, so slashes at the joints
+// don't produce double slashes, e.g. "https://gw.example.com/" and
+// "/bricklink/" become "https://gw.example.com/bricklink/api/store/v1". An
+// empty host is BrickLink's.
+func joinBaseURL(host, basePath string) string {
+	host = strings.TrimRight(host, "/")
+	if host == "" {
+		host = brickLinkAPIHost
+	}
+
+	var segments []string
+	for _, s := range strings.Split(basePath+brickLinkAPIPath, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+
+	return host + "/" + strings.Join(segments, "/")
+}
+
+// generateBaseURL generates the base URL for the signature. The params must
+// be percent encoded "key=value" pairs, they are sorted by key and value.
 func generateBaseURL(req *http.Request, params []string) string {
 	base := req.Method + "&"
 	base += encode(strings.Split(req.URL.String(), "?")[0])
 
-	// sort params
-	sort.Strings(params)
+	// sort params by key, then by value
+	sort.Slice(params, func(i, j int) bool {
+		ki, vi := splitParam(params[i])
+		kj, vj := splitParam(params[j])
+		if ki != kj {
+			return ki < kj
+		}
+		return vi < vj
+	})
 
 	paramString := strings.Join(params, "&")
 	encodedParamString := encode(paramString)
@@ -116,6 +238,33 @@ func generateBaseURL(req *http.Request, params []string) string {
 	return base
 }
 
+// encodeQuery decodes the query and returns its params as "key=value" pairs
+// percent encoded as required for the signature (RFC 3986), e.g. spaces
+// passed as "+" or "%20" both become "%20".
+func encodeQuery(rawQuery string) ([]string, error) {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var params []string
+	for k, vs := range values {
+		for _, v := range vs {
+			params = append(params, encode(k)+"="+encode(v))
+		}
+	}
+	return params, nil
+}
+
+// helper function to split a "key=value" pair
+func splitParam(param string) (key, value string) {
+	i := strings.Index(param, "=")
+	if i < 0 {
+		return param, ""
+	}
+	return param[:i], param[i+1:]
+}
+
 // generateSignature generates the OAuth signature for the request.
 // It receives the base string, the consumer secret and the token secret
 func generateSignature(base, cs, ts string) string {
@@ -1,7 +1,13 @@
 package bricklinkapi
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -26,6 +32,15 @@ func TestGenerateBaseURL(t *testing.T) {
 				"secret=1234",
 			},
 			expS: "GET&https%3A%2F%2Ffoo.com&secret%3D1234%26token%3Dabcd"}, // params are sorted
+		{desc: "testing params sorted by key before value",
+			method: "GET",
+			uri:    "https://foo.com",
+			params: []string{
+				"a-b=1",
+				"a=2",
+				"a=1",
+			},
+			expS: "GET&https%3A%2F%2Ffoo.com&a%3D1%26a%3D2%26a-b%3D1"},
 	}
 	for _, tc := range testCases {
 		req, _ := http.NewRequest(tc.method, tc.uri, nil)
@@ -36,6 +51,37 @@ func TestGenerateBaseURL(t *testing.T) {
 	}
 }
 
+func TestEncodeQuery(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		query string
+		expS  string
+	}{
+		{desc: "testing space as plus", query: "q=a+b", expS: "q=a%20b"},
+		{desc: "testing space as %20", query: "q=a%20b", expS: "q=a%20b"},
+		{desc: "testing unreserved tilde", query: "q=a~b", expS: "q=a~b"},
+		{desc: "testing encoded tilde", query: "q=a%7Eb", expS: "q=a~b"},
+		{desc: "testing reserved star", query: "q=a*b", expS: "q=a%2Ab"},
+		{desc: "testing reserved chars", query: "q=a%2Cb%26c%3D", expS: "q=a%2Cb%26c%3D"},
+		{desc: "testing unicode", query: "q=%C3%A9", expS: "q=%C3%A9"},
+		{desc: "testing encoded key", query: "a%20b=1", expS: "a%20b=1"},
+	}
+	for _, tc := range testCases {
+		params, err := encodeQuery(tc.query)
+		if err != nil || len(params) != 1 || params[0] != tc.expS {
+			t.Errorf("\n%v, want: %v, got: %v (%v)\n", tc.desc, tc.expS, params, err)
+		}
+	}
+}
+
+func TestBuildURIEncoding(t *testing.T) {
+	got := buildURI("/foo", map[string]string{"q": "a b~*é", "b": "1"})
+	exp := "/foo?b=1&q=a%20b~%2A%C3%A9"
+	if got != exp {
+		t.Errorf("\nwant: %v, got: %v\n", exp, got)
+	}
+}
+
 func TestGenerateSignature(t *testing.T) {
 	testCases := []struct {
 		desc           string
@@ -121,3 +167,159 @@ func TestEncodable(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestInterceptor(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = req
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	r := request{
+		baseURL:  srv.URL,
+		language: "de",
+		interceptors: []func(*OutgoingRequest){
+			func(out *OutgoingRequest) {
+				out.URI += "?foo=bar"
+				out.Header["X-Foo"] = "bar"
+				out.Header["Authorization"] = "overridden"
+			},
+		},
+	}
+
+	_, err := r.Request("GET", "/colors")
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if got.URL.String() != "/colors?foo=bar" {
+		t.Errorf("\nuri, want: %v, got: %v\n", "/colors?foo=bar", got.URL)
+	}
+	if got.Header.Get("X-Foo") != "bar" || got.Header.Get("Accept-Language") != "de" {
+		t.Errorf("\nheaders not set, got: %v\n", got.Header)
+	}
+	if got.Header.Get("Authorization") == "overridden" {
+		t.Errorf("\ninterceptors must not change the Authorization header\n")
+	}
+}
+
+func TestRequestMaintenancePage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<html><body>BrickLink is down for maintenance</body></html>"))
+	}))
+	defer srv.Close()
+
+	r := request{baseURL: srv.URL}
+	_, err := r.Request("GET", "/colors")
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("\nwant: %v, got: %v\n", ErrServiceUnavailable, err)
+	}
+}
+
+func TestRequestEmptyBody(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		declared bool
+		expErr   error
+	}{
+		{desc: "testing declared empty body", declared: true, expErr: nil},
+		{desc: "testing empty body of unknown length", declared: false, expErr: errEmptyBody},
+	}
+	for _, tc := range testCases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if tc.declared {
+				w.Header().Set("Content-Length", "0")
+				return
+			}
+			// flushing before writing forces a body of unknown length
+			w.(http.Flusher).Flush()
+		}))
+
+		r := request{baseURL: srv.URL}
+		_, err := r.requestContext(context.Background(), "GET", "/colors", nil)
+		if err != tc.expErr {
+			t.Errorf("\n%v, want: %v, got: %v\n", tc.desc, tc.expErr, err)
+		}
+
+		// the plain handler returns the body as is
+		_, err = r.Request("GET", "/colors")
+		if err != nil {
+			t.Errorf("\n%v, unexpected error: %v\n", tc.desc, err)
+		}
+		srv.Close()
+	}
+}
+
+func TestRequestSignatureLogging(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"meta":{"code":200},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	bl := New("ck123", "cs456", "tk789", "ts012", WithLogger(log.New(&buf, "", 0)), WithSignatureLogging())
+	bl.request.(*request).baseURL = srv.URL
+
+	_, err := bl.GetColorListParsed()
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+
+	logged := buf.String()
+	if !strings.Contains(logged, "GET /colors: GET&") || !strings.Contains(logged, "oauth_consumer_key%3DREDACTED") ||
+		!strings.Contains(logged, "oauth_token%3DREDACTED") {
+		t.Errorf("\nunexpected log: %v\n", logged)
+	}
+	for _, secret := range []string{"ck123", "cs456", "tk789", "ts012"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("\ncredential %v logged: %v\n", secret, logged)
+		}
+	}
+}
+
+func TestJoinBaseURL(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		host     string
+		basePath string
+		expS     string
+	}{
+		{desc: "testing defaults", host: "", basePath: "", expS: brickLinkAPIBaseURL},
+		{desc: "testing host only", host: "https://gw.example.com", basePath: "", expS: "https://gw.example.com/api/store/v1"},
+		{desc: "testing surrounding slashes", host: "https://gw.example.com/", basePath: "/bricklink/", expS: "https://gw.example.com/bricklink/api/store/v1"},
+		{desc: "testing missing slashes", host: "https://gw.example.com//", basePath: "bricklink", expS: "https://gw.example.com/bricklink/api/store/v1"},
+		{desc: "testing base path only", host: "", basePath: "//proxy//bricklink", expS: "https://api.bricklink.com/proxy/bricklink/api/store/v1"},
+	}
+	for _, tc := range testCases {
+		result := joinBaseURL(tc.host, tc.basePath)
+		if result != tc.expS {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expS, result)
+		}
+	}
+}
+
+func TestRequestBasePath(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		w.Write([]byte(`{"meta":{"code":200},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	bl := New("", "", "", "", WithBaseURL(srv.URL+"/"), WithBasePath("/gw/bricklink/"),
+		WithLogger(log.New(&buf, "", 0)), WithSignatureLogging())
+
+	_, err := bl.GetColorListParsed()
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+
+	if path != "/gw/bricklink/api/store/v1/colors" {
+		t.Errorf("\nunexpected path: %v\n", path)
+	}
+	if !strings.Contains(buf.String(), encode(srv.URL+"/gw/bricklink/api/store/v1/colors")) {
+		t.Errorf("\nsignature doesn't cover the full URL: %v\n", buf.String())
+	}
+}
@@ -0,0 +1,381 @@
+package bricklinkapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Meta holds the meta information BrickLink sends along with every response.
+type Meta struct {
+	Description string `json:"description"`
+	Message     string `json:"message"`
+	Code        int    `json:"code"`
+
+	// NextCursor points to the next page of a paginated list. The store
+	// API lists are not paginated at the moment, so it is usually empty.
+	NextCursor string `json:"next_cursor,omitempty"`
+	// TotalCount is the number of entries of a paginated list on all pages
+	// and Page the number of the current page, both 0 if not reported
+	TotalCount int `json:"total_count,omitempty"`
+	Page       int `json:"page,omitempty"`
+}
+
+// response is the envelope every BrickLink API response is wrapped in
+type response struct {
+	Meta Meta            `json:"meta"`
+	Data json.RawMessage `json:"data"`
+}
+
+// maxErrorBody is the maximum number of bytes of the response body kept
+// with a BrickLinkError
+const maxErrorBody = 4096
+
+// BrickLinkError is returned by the parsed methods when BrickLink responds
+// with a meta code outside of the 2xx range.
+type BrickLinkError struct {
+	Code        int
+	Message     string
+	Description string
+
+	body []byte
+}
+
+// Body returns the original response body, capped at 4KB. It never contains
+// credentials, so it is safe to log.
+func (e *BrickLinkError) Body() []byte {
+	return e.body
+}
+
+func (e *BrickLinkError) Error() string {
+	return fmt.Sprintf("bricklink error %v %v: %v", e.Code, e.Message, e.Description)
+}
+
+// UnmarshalFunc is the signature of json.Unmarshal. It allows replacing the
+// JSON library used to decode responses, see WithJSONUnmarshal.
+type UnmarshalFunc func(data []byte, v interface{}) error
+
+// decode unmarshals the response body and stores the data block in v using
+// encoding/json.
+func decode(body []byte, v interface{}) error {
+	_, err := decodeWith(json.Unmarshal, false, body, v)
+	return err
+}
+
+// decode unmarshals the response body with the configured JSON library.
+func (bl Bricklink) decode(body []byte, v interface{}) error {
+	_, err := decodeWith(bl.unmarshalFunc(), bl.strictMeta, body, v)
+	return err
+}
+
+// decodeMeta unmarshals the response body and returns its meta block. A meta
+// code outside of the 2xx range is returned as *BrickLinkError.
+func (bl Bricklink) decodeMeta(body []byte) (Meta, error) {
+	return decodeWith(bl.unmarshalFunc(), bl.strictMeta, body, nil)
+}
+
+// decodeWithMeta is like decode, but returns the meta block as well
+func (bl Bricklink) decodeWithMeta(body []byte, v interface{}) (Meta, error) {
+	return decodeWith(bl.unmarshalFunc(), bl.strictMeta, body, v)
+}
+
+// unmarshalFunc returns the configured JSON unmarshal function
+func (bl Bricklink) unmarshalFunc() UnmarshalFunc {
+	if bl.unmarshal == nil {
+		return json.Unmarshal
+	}
+	return bl.unmarshal
+}
+
+// decodeWith unmarshals the response body, stores the data block in v and
+// returns the meta block. A meta code outside of the 2xx range is returned
+// as *BrickLinkError, a missing meta block as ErrMissingMeta if strictMeta is
+// set. A nil v only checks the meta block.
+func decodeWith(unmarshal UnmarshalFunc, strictMeta bool, body []byte, v interface{}) (Meta, error) {
+	resp, err := decodeResponse(unmarshal, strictMeta, body)
+	if err != nil {
+		return resp.Meta, err
+	}
+
+	if v == nil {
+		return resp.Meta, nil
+	}
+	if len(resp.Data) == 0 || string(resp.Data) == "null" {
+		return resp.Meta, errors.New("could not decode response: data is missing")
+	}
+
+	data := reshapeEmpty(resp.Data, v)
+	err = unmarshal(data, v)
+	if err != nil {
+		return resp.Meta, fmt.Errorf("could not decode response data: %v", err)
+	}
+
+	fillExtras(unmarshal, data, reflect.ValueOf(v))
+
+	return resp.Meta, nil
+}
+
+// reshapeEmpty makes an empty object and an empty array interchangeable, as
+// BrickLink returns either for empty results: empty data is returned as the
+// empty form of the kind v points to, a slice or a struct or map. Other data,
+// including scalars, is returned as is.
+func reshapeEmpty(data []byte, v interface{}) []byte {
+	if !isEmptyJSON(data) {
+		return data
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		return []byte("[]")
+	case t.Kind() == reflect.Struct, t.Kind() == reflect.Map:
+		return []byte("{}")
+	}
+	return data
+}
+
+// helper function to check if data is an empty object or array, allowing
+// whitespace. Only the ends of data are scanned, so large payloads are
+// neither copied nor read as a whole.
+func isEmptyJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) < 2 {
+		return false
+	}
+	first, last := data[0], data[len(data)-1]
+	if !(first == '{' && last == '}') && !(first == '[' && last == ']') {
+		return false
+	}
+	return len(bytes.TrimSpace(data[1:len(data)-1])) == 0
+}
+
+// extrasField is the name of the field the parsed structs keep the fields
+// they don't model in
+const extrasField = "Extras"
+
+// fillExtras stores the fields of data which are not modeled by the struct v
+// holds in its Extras field. Slices are handled element by element. Values
+// without Extras field are left untouched.
+func fillExtras(unmarshal UnmarshalFunc, data []byte, v reflect.Value) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if !hasExtras(v.Type()) {
+			return
+		}
+		var elems []json.RawMessage
+		if unmarshal(data, &elems) != nil || len(elems) != v.Len() {
+			return
+		}
+		for i := range elems {
+			fillExtras(unmarshal, elems[i], v.Index(i))
+		}
+
+	case reflect.Struct:
+		extras := v.FieldByName(extrasField)
+		if !extras.IsValid() || extras.Type() != reflect.TypeOf(map[string]json.RawMessage(nil)) {
+			return
+		}
+		var fields map[string]json.RawMessage
+		if unmarshal(data, &fields) != nil {
+			return
+		}
+		for _, name := range jsonFieldNames(v.Type()) {
+			delete(fields, name)
+		}
+		if len(fields) != 0 {
+			extras.Set(reflect.ValueOf(fields))
+		}
+	}
+}
+
+// hasExtras reports whether t is, or consists of, a struct with Extras field
+func hasExtras(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := t.FieldByName(extrasField)
+	return ok
+}
+
+// jsonFieldNames returns the JSON names of the fields of struct type t
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			names = append(names, jsonFieldNames(f.Type)...)
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// helper function to copy a body, truncated to maxErrorBody bytes
+func capBody(body []byte) []byte {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	c := make([]byte, len(body))
+	copy(c, body)
+	return c
+}
+
+// helper function to replace a nil slice v points to with an empty one, so
+// list methods never return nil slices
+func emptySlice(v interface{}) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return
+	}
+
+	e := rv.Elem()
+	if e.Kind() == reflect.Slice && e.IsNil() {
+		e.Set(reflect.MakeSlice(e.Type(), 0, 0))
+	}
+}
+
+// decodeResponse unmarshals the response envelope and checks its meta code.
+// A response without meta code is taken as success, unless strictMeta is set.
+func decodeResponse(unmarshal UnmarshalFunc, strictMeta bool, body []byte) (resp response, err error) {
+	err = decodeEnvelope(unmarshal, strictMeta, body, &resp, &resp.Meta)
+	return resp, err
+}
+
+// decodeEnvelope unmarshals the response body into envelope and checks the
+// meta block of it meta points to, see decodeResponse
+func decodeEnvelope(unmarshal UnmarshalFunc, strictMeta bool, body []byte, envelope interface{}, meta *Meta) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errors.New("could not decode response: body is empty")
+	}
+	if isHTML(body) {
+		return serviceUnavailable(body)
+	}
+
+	err := unmarshal(body, envelope)
+	if err != nil {
+		return fmt.Errorf("could not decode response: %v", err)
+	}
+
+	if meta.Code == 0 {
+		if strictMeta {
+			return missingMeta(body)
+		}
+		return nil
+	}
+	if meta.Code < 200 || meta.Code > 299 {
+		return &BrickLinkError{
+			Code:        meta.Code,
+			Message:     meta.Message,
+			Description: meta.Description,
+			body:        capBody(body),
+		}
+	}
+
+	return nil
+}
+
+// decodeEach decodes the data list of the response body element by element
+// into the value v points to and calls fn after each element, so only a
+// single element is decoded at a time. It returns the meta block and the
+// number of elements.
+func (bl Bricklink) decodeEach(body []byte, v interface{}, fn func() error) (Meta, int, error) {
+	unmarshal := bl.unmarshalFunc()
+
+	// the envelope without data, so the list isn't copied
+	var envelope struct {
+		Meta Meta `json:"meta"`
+	}
+	err := decodeEnvelope(unmarshal, bl.strictMeta, body, &envelope, &envelope.Meta)
+	if err != nil {
+		return envelope.Meta, 0, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	delim, err := seekData(dec)
+	if err != nil {
+		return envelope.Meta, 0, fmt.Errorf("could not decode response: %v", err)
+	}
+	if delim == nil {
+		return envelope.Meta, 0, errors.New("could not decode response: data is missing")
+	}
+	// an empty object is an empty list, see reshapeEmpty
+	if delim != json.Delim('[') && (delim != json.Delim('{') || dec.More()) {
+		return envelope.Meta, 0, errors.New("could not decode response data: data is not a list")
+	}
+
+	elem := reflect.ValueOf(v).Elem()
+	var n int
+	for dec.More() {
+		var data json.RawMessage
+		err = dec.Decode(&data)
+		if err != nil {
+			return envelope.Meta, n, fmt.Errorf("could not decode response data: %v", err)
+		}
+		elem.Set(reflect.Zero(elem.Type()))
+		err = unmarshal(data, v)
+		if err != nil {
+			return envelope.Meta, n, fmt.Errorf("could not decode response data: %v", err)
+		}
+		fillExtras(unmarshal, data, reflect.ValueOf(v))
+
+		n++
+		err = fn()
+		if err != nil {
+			return envelope.Meta, n, err
+		}
+	}
+
+	return envelope.Meta, n, nil
+}
+
+// helper function to advance dec into the data field of the response
+// envelope. It returns the first token of the data, nil if data is null or
+// missing.
+func seekData(dec *json.Decoder) (json.Token, error) {
+	_, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if key == "data" {
+			return dec.Token()
+		}
+
+		var skip json.RawMessage
+		err = dec.Decode(&skip)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
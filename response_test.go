@@ -0,0 +1,217 @@
+package bricklinkapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		desc string
+		body string
+		code int // expected BrickLinkError code, 0 for success
+	}{
+		{desc: "testing success",
+			body: `{"meta":{"code":200,"message":"OK"},"data":{"color_id":1}}`},
+		{desc: "testing error meta",
+			body: `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"},"data":{}}`,
+			code: 404},
+	}
+	for _, tc := range testCases {
+		var c Color
+		err := decode([]byte(tc.body), &c)
+		if tc.code == 0 {
+			if err != nil || c.ColorID != 1 {
+				t.Errorf("\n%v, unexpected result: %+v (%v)\n", tc.desc, c, err)
+			}
+			continue
+		}
+
+		blErr, ok := err.(*BrickLinkError)
+		if !ok || blErr.Code != tc.code {
+			t.Errorf("\n%v, want: code %v, got: %v\n", tc.desc, tc.code, err)
+			continue
+		}
+		if !bytes.Equal(blErr.Body(), []byte(tc.body)) {
+			t.Errorf("\n%v, body not attached to error, got: %s\n", tc.desc, blErr.Body())
+		}
+	}
+}
+
+func TestListMethodsMalformed(t *testing.T) {
+	bodies := []string{
+		``,
+		`garbage`,
+		`{"meta":{"code":200}}`,
+		`{"meta":{"code":200},"data":null}`,
+		`{"meta":{"code":200},"data":{"foo":"bar"}}`,
+	}
+
+	for _, body := range bodies {
+		bl := New("", "", "", "")
+		bl.request = &fakeRequest{body: []byte(body)}
+
+		methods := map[string]func() (int, bool, error){
+			"colors": func() (int, bool, error) {
+				l, err := bl.GetColorListParsed()
+				return len(l), l == nil, err
+			},
+			"categories": func() (int, bool, error) {
+				l, err := bl.GetCategoryListParsed()
+				return len(l), l == nil, err
+			},
+			"inventories": func() (int, bool, error) {
+				l, err := bl.GetInventoryListParsed(nil)
+				return len(l), l == nil, err
+			},
+			"orders": func() (int, bool, error) {
+				l, err := bl.GetOrdersParsed(nil)
+				return len(l), l == nil, err
+			},
+			"order items": func() (int, bool, error) {
+				l, err := bl.GetOrderItemsParsed(1)
+				return len(l), l == nil, err
+			},
+			"subsets": func() (int, bool, error) {
+				l, err := bl.GetSubsetsParsed("SET", "1-1", nil)
+				return len(l), l == nil, err
+			},
+		}
+
+		for name, m := range methods {
+			n, isNil, err := m()
+			if err == nil {
+				t.Errorf("\n%v with body %q, want error, got nil\n", name, body)
+			}
+			if n != 0 || isNil {
+				t.Errorf("\n%v with body %q, want empty slice, got len %v (nil: %v)\n", name, body, n, isNil)
+			}
+		}
+	}
+}
+
+func TestDecodeExtras(t *testing.T) {
+	body := []byte(`{"meta":{"code":200},"data":[{"color_id":1,"color_name":"White","new_field":"foo"},{"color_id":2}]}`)
+
+	var colors []Color
+	err := decode(body, &colors)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if len(colors[0].Extras) != 1 || string(colors[0].Extras["new_field"]) != `"foo"` {
+		t.Errorf("\nunmodeled field not captured, got: %v\n", colors[0].Extras)
+	}
+	if colors[1].Extras != nil {
+		t.Errorf("\nmodeled fields should not be captured, got: %v\n", colors[1].Extras)
+	}
+}
+
+func TestDecodeDataShapes(t *testing.T) {
+	var colors []Color
+	var color Color
+	var counts map[string]int
+	var name string
+	var number int
+	var raw []byte
+
+	testCases := []struct {
+		desc string
+		data string
+		v    interface{}
+		err  bool
+	}{
+		{desc: "empty object as slice", data: `{}`, v: &colors},
+		{desc: "empty array as slice", data: `[ ]`, v: &colors},
+		{desc: "empty array as struct", data: `[]`, v: &color},
+		{desc: "empty array as map", data: `[]`, v: &counts},
+		{desc: "string scalar", data: `"ok"`, v: &name},
+		{desc: "number scalar", data: `42`, v: &number},
+		{desc: "empty object as scalar", data: `{}`, v: &number, err: true},
+		{desc: "object as slice", data: `{"color_id":1}`, v: &colors, err: true},
+		{desc: "empty array as bytes", data: `[]`, v: &raw},
+	}
+	for _, tc := range testCases {
+		err := decode([]byte(`{"meta":{"code":200},"data":`+tc.data+`}`), tc.v)
+		if (err != nil) != tc.err {
+			t.Errorf("\n%v, unexpected error: %v\n", tc.desc, err)
+		}
+	}
+	if name != "ok" || number != 42 || colors == nil || len(colors) != 0 {
+		t.Errorf("\nunexpected values: %q %v %v\n", name, number, colors)
+	}
+}
+
+func TestWithJSONUnmarshal(t *testing.T) {
+	var calls int
+	unmarshal := func(data []byte, v interface{}) error {
+		calls++
+		return json.Unmarshal(data, v)
+	}
+
+	bl := New("", "", "", "", WithJSONUnmarshal(unmarshal))
+	bl.request = &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[{"color_id":1,"color_name":"White"}]}`)}
+
+	colors, err := bl.GetColorListParsed()
+	if err != nil || len(colors) != 1 || colors[0].ColorName != "White" {
+		t.Errorf("\nunexpected result: %+v (%v)\n", colors, err)
+	}
+	if calls == 0 {
+		t.Errorf("\ninjected unmarshal function was not called\n")
+	}
+}
+
+func TestIsEmptyJSON(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    string
+		exp  bool
+	}{
+		{desc: "testing empty object", s: "{}", exp: true},
+		{desc: "testing empty array with whitespace", s: " [ \n\t] ", exp: true},
+		{desc: "testing mismatched brackets", s: "{]", exp: false},
+		{desc: "testing array", s: `[{"no":"3001"}]`, exp: false},
+		{desc: "testing string", s: `"{}"`, exp: false},
+		{desc: "testing empty data", s: "", exp: false},
+	}
+	for _, tc := range testCases {
+		result := isEmptyJSON([]byte(tc.s))
+		if result != tc.exp {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.exp, result)
+		}
+	}
+}
+
+func TestDecodeEach(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data string
+		expS string
+		err  bool
+	}{
+		{desc: "testing list", data: `[{"color_id":1},{"color_id":5}]`, expS: "1,5"},
+		{desc: "testing empty list", data: `[]`, expS: ""},
+		{desc: "testing empty object", data: `{}`, expS: ""},
+		{desc: "testing object", data: `{"color_id":1}`, err: true},
+		{desc: "testing null", data: `null`, err: true},
+	}
+	for _, tc := range testCases {
+		bl := New("", "", "", "")
+		body := []byte(`{"meta":{"code":200},"data":` + tc.data + `}`)
+
+		var ids []string
+		var c Color
+		_, n, err := bl.decodeEach(body, &c, func() error {
+			ids = append(ids, strconv.Itoa(c.ColorID))
+			return nil
+		})
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if strings.Join(ids, ",") != tc.expS || n != len(ids) {
+			t.Errorf("%v, want: %v, got: %v (%v elements)\n", tc.desc, tc.expS, ids, n)
+		}
+	}
+}
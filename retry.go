@@ -0,0 +1,56 @@
+package bricklinkapi
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type noRetryKey struct{}
+
+// WithoutRetry returns a context which disables retries for the requests
+// issued with it, e.g. for user initiated calls that should rather fail fast
+// than wait through the backoff. Pass it to the methods taking a context, or
+// to WithBaseContext to disable retries for the methods without one.
+func WithoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// retryable reports whether a failed request is worth retrying. Only
+// transport errors are, as long as the context is still alive and retries
+// are not disabled for it.
+func retryable(ctx context.Context, err error) bool {
+	if err == nil || !retryAllowed(ctx) {
+		return false
+	}
+
+	var te *transportError
+	return errors.As(err, &te)
+}
+
+// retryAllowed reports whether ctx is still alive and has retries enabled
+func retryAllowed(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	disabled, _ := ctx.Value(noRetryKey{}).(bool)
+	return !disabled
+}
+
+// wait blocks for the backoff of the given attempt or until ctx is done
+func (bl Bricklink) wait(ctx context.Context, attempt int) error {
+	d := bl.backoff << uint(attempt)
+	if bl.sleep != nil {
+		return bl.sleep(ctx, d)
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
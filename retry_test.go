@@ -0,0 +1,214 @@
+package bricklinkapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type scriptedResult struct {
+	body string
+	err  error
+}
+
+// scriptedRequest is a request handler returning the scripted results in
+// order and recording the methods, uris and payloads of the requests it
+// received
+type scriptedRequest struct {
+	mu       sync.Mutex
+	results  []scriptedResult
+	methods  []string
+	uris     []string
+	payloads []string
+}
+
+func (s *scriptedRequest) Request(method, uri string) ([]byte, error) {
+	return s.requestContext(context.Background(), method, uri, nil)
+}
+
+func (s *scriptedRequest) requestContext(ctx context.Context, method, uri string, payload []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.methods = append(s.methods, method)
+	s.uris = append(s.uris, uri)
+	s.payloads = append(s.payloads, string(payload))
+	if len(s.results) == 0 {
+		return nil, errors.New("no scripted result left")
+	}
+	r := s.results[0]
+	s.results = s.results[1:]
+	return []byte(r.body), r.err
+}
+
+var errNetwork = &transportError{errors.New("connection reset")}
+
+func TestRetryGet(t *testing.T) {
+	s := &scriptedRequest{results: []scriptedResult{
+		{err: errNetwork},
+		{err: errNetwork},
+		{body: `{"meta":{"code":200},"data":[]}`},
+	}}
+	bl := New("", "", "", "", WithRetry(2, time.Millisecond))
+	bl.request = s
+
+	_, err := bl.GetColorListParsed()
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	if len(s.methods) != 3 {
+		t.Errorf("\nwant: %v requests, got: %v\n", 3, len(s.methods))
+	}
+}
+
+func TestRetryWriteVerified(t *testing.T) {
+	s := &scriptedRequest{results: []scriptedResult{
+		{err: errNetwork},
+		{body: `{"meta":{"code":200},"data":{"order_id":1,"status":"PACKED"}}`},
+	}}
+	bl := New("", "", "", "", WithRetry(2, time.Millisecond), WithVerifiedWrites())
+	bl.request = s
+
+	err := bl.UpdateOrderStatus(1, "PACKED")
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	if len(s.methods) != 2 || s.methods[0] != "PUT" || s.methods[1] != "GET" {
+		t.Errorf("\nwrite should be verified instead of resent, got: %v\n", s.methods)
+	}
+}
+
+func TestRetryWriteVerificationFailed(t *testing.T) {
+	s := &scriptedRequest{results: []scriptedResult{
+		{err: errNetwork},
+		{body: `{"meta":{"code":500,"message":"SERVER_ERROR"}}`},
+		{body: `{"meta":{"code":200},"data":{}}`},
+	}}
+	bl := New("", "", "", "", WithRetry(2, time.Millisecond), WithVerifiedWrites())
+	bl.request = s
+
+	err := bl.UpdateOrderStatus(1, "PACKED")
+	if !errors.Is(err, errNetwork) {
+		t.Errorf("\nwant: the write error, got: %v\n", err)
+	}
+	if len(s.methods) != 2 || s.methods[0] != "PUT" || s.methods[1] != "GET" {
+		t.Errorf("\nwrite must not be resent unverified, got: %v\n", s.methods)
+	}
+}
+
+func TestRetryWriteUnverified(t *testing.T) {
+	s := &scriptedRequest{results: []scriptedResult{
+		{err: errNetwork},
+	}}
+	bl := New("", "", "", "", WithRetry(2, time.Millisecond))
+	bl.request = s
+
+	err := bl.UpdateOrderStatus(1, "PACKED")
+	if err == nil {
+		t.Errorf("\nwant error, got nil\n")
+	}
+	if len(s.methods) != 1 {
+		t.Errorf("\nwrites must not be retried without verification, got: %v\n", s.methods)
+	}
+}
+
+func TestWithoutRetry(t *testing.T) {
+	s := &scriptedRequest{results: []scriptedResult{
+		{err: errNetwork},
+		{body: `{"meta":{"code":200},"data":[]}`},
+	}}
+	bl := New("", "", "", "", WithRetry(2, time.Millisecond))
+	bl.request = s
+
+	_, err := bl.colorList(WithoutRetry(context.Background()))
+	if err == nil {
+		t.Errorf("\nwant error, got nil\n")
+	}
+	if len(s.methods) != 1 {
+		t.Errorf("\nretries should be disabled, got: %v requests\n", len(s.methods))
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	s := &scriptedRequest{results: []scriptedResult{
+		{err: errNetwork},
+		{err: errNetwork},
+		{err: errNetwork},
+		{body: `{"meta":{"code":200},"data":[]}`},
+	}}
+	bl := New("", "", "", "", WithRetry(3, time.Hour))
+	bl.request = s
+
+	var waits []time.Duration
+	bl.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	_, err := bl.GetColorListParsed()
+	if err != nil {
+		t.Errorf("\nunexpected error: %v\n", err)
+	}
+	want := []time.Duration{time.Hour, 2 * time.Hour, 4 * time.Hour}
+	if len(waits) != len(want) || waits[0] != want[0] || waits[1] != want[1] || waits[2] != want[2] {
+		t.Errorf("\nwant: %v\ngot: %v\n", want, waits)
+	}
+}
+
+func TestRetryTruncatedBody(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		opts     []Option
+		requests int
+		err      bool
+	}{
+		{desc: "testing without retries", opts: nil, requests: 1, err: true},
+		{desc: "testing with retries", opts: []Option{WithRetry(1, time.Millisecond)}, requests: 2},
+	}
+	for _, tc := range testCases {
+		s := &scriptedRequest{results: []scriptedResult{
+			{body: `{"meta":{"code":200},"data":[{"color_id":1`},
+			{body: `{"meta":{"code":200},"data":[{"color_id":1}]}`},
+		}}
+		bl := New("", "", "", "", tc.opts...)
+		bl.request = s
+
+		_, err := bl.GetColorListParsed()
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+		}
+		if len(s.methods) != tc.requests {
+			t.Errorf("%v, want: %v requests, got: %v\n", tc.desc, tc.requests, len(s.methods))
+		}
+	}
+}
+
+func TestRetryEmptyBody(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		opts     []Option
+		expBody  string
+		requests int
+	}{
+		{desc: "testing without retries", opts: nil, expBody: "", requests: 1},
+		{desc: "testing with retries", opts: []Option{WithRetry(1, time.Millisecond)}, expBody: "{}", requests: 2},
+	}
+	for _, tc := range testCases {
+		s := &scriptedRequest{results: []scriptedResult{
+			{err: errEmptyBody},
+			{body: "{}"},
+		}}
+		bl := New("", "", "", "", tc.opts...)
+		bl.request = s
+
+		body, err := bl.send(context.Background(), "GET", "/colors", nil)
+		if err != nil || string(body) != tc.expBody {
+			t.Errorf("\n%v, want: %q, got: %q, %v\n", tc.desc, tc.expBody, body, err)
+		}
+		if len(s.methods) != tc.requests {
+			t.Errorf("\n%v, want: %v requests, got: %v\n", tc.desc, tc.requests, len(s.methods))
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package bricklinkapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// WeightSource tells where the weight of an order was taken from.
+type WeightSource string
+
+// Weight sources of OrderWeight
+const (
+	// WeightFromOrder is the total weight reported for the order
+	WeightFromOrder WeightSource = "order"
+	// WeightFromItems is the sum of the weights of the order items
+	WeightFromItems WeightSource = "items"
+)
+
+// ShippingRate is the cost of shipping orders weighing up to UpTo grams. An
+// UpTo of 0 covers any weight.
+type ShippingRate struct {
+	UpTo float64
+	Cost Money
+}
+
+// ShippingEstimate is the result of EstimateShipping.
+type ShippingEstimate struct {
+	Cost Money
+	// Weight is the weight in grams the estimate is based on
+	Weight float64
+	Source WeightSource
+}
+
+// OrderWeight returns the weight of the order in grams. The total weight
+// reported by BrickLink is used if present; if it is missing or zero the
+// weights of the items, as returned by GetOrderItemsParsed, are summed.
+func OrderWeight(order Order, items [][]OrderItem) (float64, WeightSource, error) {
+	weight, err := parseWeight(order.TotalWeight)
+	if err != nil {
+		return 0, "", fmt.Errorf("order %v: %v", order.OrderID, err)
+	}
+	if weight > 0 {
+		return weight, WeightFromOrder, nil
+	}
+
+	var sum float64
+	for _, batch := range items {
+		for _, item := range batch {
+			w, err := parseWeight(item.Weight)
+			if err != nil {
+				return 0, "", fmt.Errorf("item %v %v: %v", item.Item.Type, item.Item.No, err)
+			}
+			sum += w * float64(item.Quantity)
+		}
+	}
+
+	return sum, WeightFromItems, nil
+}
+
+// EstimateShipping estimates the shipping cost of the order from the rates,
+// which are supplied by the caller like a FeeSchedule. The rate with the
+// lowest UpTo covering the weight of the order, see OrderWeight, is used.
+func EstimateShipping(order Order, items [][]OrderItem, rates []ShippingRate) (ShippingEstimate, error) {
+	weight, source, err := OrderWeight(order, items)
+	if err != nil {
+		return ShippingEstimate{}, err
+	}
+
+	var rate *ShippingRate
+	for i, r := range rates {
+		if r.UpTo != 0 && r.UpTo < weight {
+			continue
+		}
+		if rate == nil || rate.UpTo == 0 || r.UpTo != 0 && r.UpTo < rate.UpTo {
+			rate = &rates[i]
+		}
+	}
+	if rate == nil {
+		return ShippingEstimate{}, fmt.Errorf("no shipping rate covers %v grams", weight)
+	}
+
+	return ShippingEstimate{Cost: rate.Cost, Weight: weight, Source: source}, nil
+}
+
+// helper function to parse a weight in grams, an empty weight is 0
+func parseWeight(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	w, err := strconv.ParseFloat(s, 64)
+	if err != nil || w < 0 {
+		return 0, errors.New("invalid weight " + strconv.Quote(s))
+	}
+	return w, nil
+}
+
+// ShippingCondition is a condition an order must meet to be shipped, it
+// returns an error describing why the order doesn't meet it.
+type ShippingCondition func(order Order) error
+
+// Conditions checked by ValidateForShipping
+var (
+	// RequirePayment requires the payment to be received or completed
+	RequirePayment ShippingCondition = func(order Order) error {
+		switch order.Payment.Status {
+		case PaymentReceived, PaymentCompleted:
+			return nil
+		}
+		return fmt.Errorf("payment status is \"%v\"", order.Payment.Status)
+	}
+
+	// RequireAddress requires a shipping address
+	RequireAddress ShippingCondition = func(order Order) error {
+		a := order.Shipping.Address
+		if strings.TrimSpace(a.Full) == "" && strings.TrimSpace(a.Address1) == "" {
+			return errors.New("shipping address is missing")
+		}
+		return nil
+	}
+
+	// RequireItems requires the order to contain items
+	RequireItems ShippingCondition = func(order Order) error {
+		if order.TotalCount <= 0 || order.UniqueCount <= 0 {
+			return errors.New("order has no items")
+		}
+		return nil
+	}
+)
+
+// ValidateForShipping checks whether the order is ready to be shipped. With
+// no conditions given, RequirePayment, RequireAddress and RequireItems are
+// checked; otherwise only the given ones. The unmet conditions are returned
+// as MultiError.
+func ValidateForShipping(order Order, conditions ...ShippingCondition) error {
+	if len(conditions) == 0 {
+		conditions = []ShippingCondition{RequirePayment, RequireAddress, RequireItems}
+	}
+
+	var merr MultiError
+	for _, condition := range conditions {
+		err := condition(order)
+		if err != nil {
+			merr = append(merr, fmt.Errorf("order %v: %v", order.OrderID, err))
+		}
+	}
+
+	return merr.errOrNil()
+}
@@ -0,0 +1,90 @@
+package bricklinkapi
+
+import (
+	"testing"
+)
+
+func TestEstimateShipping(t *testing.T) {
+	items := [][]OrderItem{{
+		{Item: Item{No: "3001", Type: "PART"}, Quantity: 10, Weight: "2.32"},
+		{Item: Item{No: "3002", Type: "PART"}, Quantity: 4, Weight: "1.5"},
+	}}
+	rates := []ShippingRate{
+		{UpTo: 0, Cost: Money{Amount: 150000}},
+		{UpTo: 100, Cost: Money{Amount: 50000}},
+		{UpTo: 20, Cost: Money{Amount: 20000}},
+	}
+
+	testCases := []struct {
+		desc   string
+		weight string
+		items  [][]OrderItem
+		cost   int64
+		grams  float64
+		source WeightSource
+		err    bool
+	}{
+		{desc: "testing order weight", weight: "85.00", items: items, cost: 50000, grams: 85, source: WeightFromOrder},
+		{desc: "testing item weights", weight: "0.00", items: items, cost: 50000, grams: 29.2, source: WeightFromItems},
+		{desc: "testing no weight", weight: "", items: nil, cost: 20000, grams: 0, source: WeightFromItems},
+		{desc: "testing open ended rate", weight: "500", items: nil, cost: 150000, grams: 500, source: WeightFromOrder},
+		{desc: "testing invalid weight", weight: "heavy", items: items, err: true},
+	}
+	for _, tc := range testCases {
+		est, err := EstimateShipping(Order{TotalWeight: tc.weight}, tc.items, rates)
+		if (err != nil) != tc.err {
+			t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+			continue
+		}
+		if est.Cost.Amount != tc.cost || est.Source != tc.source || est.Weight-tc.grams > 1e-9 || tc.grams-est.Weight > 1e-9 {
+			t.Errorf("%v, want: %v %v %v, got: %+v\n", tc.desc, tc.cost, tc.grams, tc.source, est)
+		}
+	}
+
+	_, err := EstimateShipping(Order{TotalWeight: "500"}, nil, rates[1:])
+	if err == nil {
+		t.Errorf("\nexpected error without a covering rate\n")
+	}
+}
+
+func TestValidateForShipping(t *testing.T) {
+	ready := Order{OrderID: 1, TotalCount: 3, UniqueCount: 2}
+	ready.Payment.Status = PaymentReceived
+	ready.Shipping.Address.Full = "1 Brick Lane, Billund"
+
+	unpaid := ready
+	unpaid.Payment.Status = PaymentSent
+
+	empty := Order{OrderID: 2}
+	empty.Payment.Status = PaymentNone
+
+	testCases := []struct {
+		desc       string
+		order      Order
+		conditions []ShippingCondition
+		unmet      int
+	}{
+		{desc: "testing ready order", order: ready, conditions: nil, unmet: 0},
+		{desc: "testing unpaid order", order: unpaid, conditions: nil, unmet: 1},
+		{desc: "testing unpaid order without payment condition", order: unpaid, conditions: []ShippingCondition{RequireAddress, RequireItems}, unmet: 0},
+		{desc: "testing empty order", order: empty, conditions: nil, unmet: 3},
+		{desc: "testing empty order with items condition", order: empty, conditions: []ShippingCondition{RequireItems}, unmet: 1},
+	}
+	for _, tc := range testCases {
+		err := ValidateForShipping(tc.order, tc.conditions...)
+		if tc.unmet == 0 {
+			if err != nil {
+				t.Errorf("%v, unexpected error: %v\n", tc.desc, err)
+			}
+			continue
+		}
+
+		merr, ok := err.(MultiError)
+		if !ok {
+			t.Fatalf("%v, want MultiError, got %T: %v\n", tc.desc, err, err)
+		}
+		if len(merr) != tc.unmet {
+			t.Errorf("%v, want: %v unmet conditions, got: %v\n", tc.desc, tc.unmet, merr)
+		}
+	}
+}
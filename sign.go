@@ -0,0 +1,91 @@
+package bricklinkapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Creds are the OAuth credentials of a BrickLink API consumer.
+type Creds struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	Token          string
+	TokenSecret    string
+}
+
+// redacted replaces the credentials in logged signature base strings
+const redacted = "REDACTED"
+
+// SignRequest returns the OAuth Authorization header for a request, built by
+// the same code that signs the requests of the handler. The query of fullURL
+// and params, e.g. those of a form encoded body, are covered by the
+// signature. A URL which can't be parsed yields an empty header.
+func SignRequest(creds Creds, method, fullURL string, params url.Values) (authHeader string) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	authHeader, _, _ = signRequest(creds, method, fullURL, params, timestamp, newNonce())
+	return authHeader
+}
+
+// signRequest signs the request with the given timestamp and nonce and
+// returns the Authorization header and the signature base string
+func signRequest(creds Creds, method, fullURL string, params url.Values, timestamp, nonce string) (authHeader, base string, err error) {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return "", "", fmt.Errorf("could not parse url: %v", err)
+	}
+
+	// construct values for oauth params
+	var oauthParams []string
+	oauthParams = append(oauthParams, "oauth_consumer_key="+encode(creds.ConsumerKey))
+	oauthParams = append(oauthParams, "oauth_token="+encode(creds.Token))
+	oauthParams = append(oauthParams, "oauth_signature_method="+oauthSignatureMethod)
+	oauthParams = append(oauthParams, "oauth_timestamp="+timestamp)
+	oauthParams = append(oauthParams, "oauth_nonce="+encode(nonce))
+	oauthParams = append(oauthParams, "oauth_version="+oauthVersion)
+
+	// extract uri params from URI and add to oauth params
+	queryParams, err := encodeQuery(u.RawQuery)
+	if err != nil {
+		return "", "", fmt.Errorf("could not parse query: %v", err)
+	}
+	oauthParams = append(oauthParams, queryParams...)
+	for k, vs := range params {
+		for _, v := range vs {
+			oauthParams = append(oauthParams, encode(k)+"="+encode(v))
+		}
+	}
+
+	// generate signature
+	base = generateBaseURL(&http.Request{Method: method, URL: u}, oauthParams)
+	signature := generateSignature(base, creds.ConsumerSecret, creds.TokenSecret)
+
+	// build authorization string for the header
+	authHeader = "OAuth "
+	authHeader += "oauth_consumer_key=\"" + encode(creds.ConsumerKey) + "\","
+	authHeader += "oauth_token=\"" + encode(creds.Token) + "\","
+	authHeader += "oauth_signature_method=\"" + oauthSignatureMethod + "\","
+	authHeader += "oauth_signature=\"" + signature + "\","
+	authHeader += "oauth_timestamp=\"" + timestamp + "\","
+	authHeader += "oauth_nonce=\"" + encode(nonce) + "\","
+	authHeader += "oauth_version=\"" + oauthVersion + "\""
+
+	return authHeader, base, nil
+}
+
+// newNonce returns the nonce of a request, 16 random bytes hex encoded. As
+// concurrent requests share the timestamp, the nonce must be unique for
+// each of them.
+func newNonce() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		// the system's random source is broken, fall back to the clock
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,84 @@
+package bricklinkapi
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSignRequest(t *testing.T) {
+	creds := Creds{ConsumerKey: "ck", ConsumerSecret: "cs", Token: "t", TokenSecret: "ts"}
+	fullURL := "https://api.bricklink.com/api/store/v1/items/PART/3001/price?guide_type=sold"
+
+	header := SignRequest(creds, "GET", fullURL, nil)
+	m := regexp.MustCompile(`^OAuth oauth_consumer_key="ck",oauth_token="t",oauth_signature_method="HMAC-SHA1",oauth_signature="([^"]+)",oauth_timestamp="(\d+)",oauth_nonce="([0-9a-f]+)",oauth_version="1.0"$`).FindStringSubmatch(header)
+	if m == nil {
+		t.Fatalf("\nunexpected header: %v\n", header)
+	}
+
+	// the header is reproducible from its timestamp and nonce
+	want, _, err := signRequest(creds, "GET", fullURL, nil, m[2], m[3])
+	if err != nil || header != want {
+		t.Errorf("\nwant: %v\ngot: %v (%v)\n", want, header, err)
+	}
+
+	// params are covered by the signature like the query
+	withParams, _, _ := signRequest(creds, "GET", "https://api.bricklink.com/api/store/v1/items/PART/3001/price", url.Values{"guide_type": {"sold"}}, m[2], m[3])
+	if withParams != header {
+		t.Errorf("\nwant: %v\ngot: %v\n", header, withParams)
+	}
+
+	if SignRequest(creds, "GET", "https://api.bricklink.com/?a=%zz", nil) != "" {
+		t.Errorf("\nwant empty header for an invalid query\n")
+	}
+}
+
+// TestSignRequestPublishedExample reproduces the HMAC-SHA1 example of
+// Twitter's "Creating a signature" guide byte for byte. BrickLink doesn't
+// publish a complete signing example of its own, but uses the same OAuth 1.0a
+// algorithm.
+func TestSignRequestPublishedExample(t *testing.T) {
+	creds := Creds{
+		ConsumerKey:    "xvz1evFS4wEEPTGEFPHBog",
+		ConsumerSecret: "kAcSOqF21Fu85e7zjz7ZN2U4ZRhfV3WpwPAoE3Z7kBw",
+		Token:          "370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb",
+		TokenSecret:    "LswwdoUaIvS8ltyTt5jkRh4J50vUPVVHtR2YPi5kE",
+	}
+	params := url.Values{"status": {"Hello Ladies + Gentlemen, a signed OAuth request!"}}
+
+	header, base, err := signRequest(creds, "POST", "https://api.twitter.com/1.1/statuses/update.json?include_entities=true",
+		params, "1318622958", "kYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg")
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+
+	wantBase := "POST&https%3A%2F%2Fapi.twitter.com%2F1.1%2Fstatuses%2Fupdate.json&" +
+		"include_entities%3Dtrue%26oauth_consumer_key%3Dxvz1evFS4wEEPTGEFPHBog%26" +
+		"oauth_nonce%3DkYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg%26oauth_signature_method%3DHMAC-SHA1%26" +
+		"oauth_timestamp%3D1318622958%26oauth_token%3D370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb%26" +
+		"oauth_version%3D1.0%26status%3DHello%2520Ladies%2520%252B%2520Gentlemen%252C%2520a%2520signed%2520OAuth%2520request%2521"
+	if base != wantBase {
+		t.Errorf("\nwant base: %v\ngot: %v\n", wantBase, base)
+	}
+
+	// hCtSmYh+iHYCEqBWrE7C7hYmtUk= percent encoded
+	wantSignature := `oauth_signature="hCtSmYh%2BiHYCEqBWrE7C7hYmtUk%3D"`
+	if !strings.Contains(header, wantSignature) {
+		t.Errorf("\nwant: %v\ngot: %v\n", wantSignature, header)
+	}
+}
+
+func TestNewNonce(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		nonce := newNonce()
+		if len(nonce) != 32 {
+			t.Errorf("\nunexpected nonce: %v\n", nonce)
+		}
+		if seen[nonce] {
+			t.Fatalf("\nnonce %v returned twice\n", nonce)
+		}
+		seen[nonce] = true
+	}
+}
@@ -0,0 +1,298 @@
+package bricklinkapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	subsetItemTypes = []string{"MINIFIG", "PART", "SET", "BOOK", "GEAR"}
+)
+
+// SubsetItem is a single item an item consists of.
+type SubsetItem struct {
+	Item          Item `json:"item"`
+	ColorID       int  `json:"color_id"`
+	Quantity      int  `json:"quantity"`
+	ExtraQuantity int  `json:"extra_quantity"`
+	IsAlternate   bool `json:"is_alternate"`
+	IsCounterpart bool `json:"is_counterpart"`
+}
+
+// Subset is a group of subset items sharing the same match number. Entries
+// besides the first are alternates of each other.
+type Subset struct {
+	MatchNo int          `json:"match_no"`
+	Entries []SubsetItem `json:"entries"`
+}
+
+// GetSubsets issues a GET request to the Bricklink API and querys for the items
+// the specified item consists of. Subsets are available for MINIFIG, PART, SET,
+// BOOK and GEAR. Params are passed on as query parameters (e.g. break_minifigs).
+func (bl Bricklink) GetSubsets(itemType, itemNumber string, params map[string]string) (response string, err error) {
+	body, err := bl.GetSubsetsBytes(itemType, itemNumber, params)
+	return string(body), err
+}
+
+// GetSubsetsBytes is like GetSubsets but returns the response body as is,
+// without the copy of the string conversion.
+func (bl Bricklink) GetSubsetsBytes(itemType, itemNumber string, params map[string]string) (response []byte, err error) {
+	uri, err := bl.subsetsURI(itemType, itemNumber, params)
+	if err != nil {
+		return response, err
+	}
+
+	body, err := bl.send(bl.context(), "GET", uri, nil)
+	if err != nil {
+		return response, err
+	}
+
+	return body, nil
+}
+
+// GetSubsetsParsed querys for the subsets of the specified item and returns them parsed.
+func (bl Bricklink) GetSubsetsParsed(itemType, itemNumber string, params map[string]string) (subsets []Subset, err error) {
+	uri, err := bl.subsetsURI(itemType, itemNumber, params)
+	if err != nil {
+		return subsets, err
+	}
+
+	err = bl.getParsed(bl.context(), uri, &subsets)
+	return subsets, err
+}
+
+// GetMinifigParts returns the parts the specified minifig consists of, with
+// colors and quantities. Alternates and counterparts are left out.
+func (bl Bricklink) GetMinifigParts(minifigNumber string) (parts []SubsetItem, err error) {
+	subsets, err := bl.GetSubsetsParsed("MINIFIG", minifigNumber, nil)
+	if err != nil {
+		return parts, err
+	}
+
+	return flattenSubsets(subsets), nil
+}
+
+// SetPart is a part of a set as returned by GetSetParts.
+type SetPart struct {
+	SubsetItem
+
+	// IsObsolete is only set with WithObsoleteStatus
+	IsObsolete bool
+}
+
+// SubsetOption configures GetSetParts and GetSubsetsDeep.
+type SubsetOption func(*subsetOptions)
+
+type subsetOptions struct {
+	obsolete bool
+	maxDepth int
+}
+
+// defaultMaxDepth is the number of levels GetSubsetsDeep expands by default
+const defaultMaxDepth = 5
+
+// WithMaxDepth limits the number of levels GetSubsetsDeep expands, 1 only
+// expands the set itself. Values below 1 are ignored.
+func WithMaxDepth(depth int) SubsetOption {
+	return func(o *subsetOptions) {
+		if depth >= 1 {
+			o.maxDepth = depth
+		}
+	}
+}
+
+// WithObsoleteStatus makes GetSetParts look up the catalog entry of every
+// part to report whether it is obsolete, i.e. hard to source. Each distinct
+// item is fetched once, served from the cache if enabled.
+func WithObsoleteStatus() SubsetOption {
+	return func(o *subsetOptions) {
+		o.obsolete = true
+	}
+}
+
+// GetSetParts expands the set into its parts, skipping alternates and
+// counterparts. Catalog lookups of WithObsoleteStatus which fail leave the
+// status unset; their errors are returned as MultiError.
+func (bl Bricklink) GetSetParts(ctx context.Context, setNumber string, opts ...SubsetOption) ([]SetPart, error) {
+	var o subsetOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	uri, err := bl.subsetsURI("SET", setNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subsets []Subset
+	err = bl.getParsed(ctx, uri, &subsets)
+	if err != nil {
+		return nil, err
+	}
+
+	items := flattenSubsets(subsets)
+	parts := make([]SetPart, len(items))
+	for i, it := range items {
+		parts[i].SubsetItem = it
+	}
+	if !o.obsolete {
+		return parts, nil
+	}
+
+	// look up distinct items
+	var keys []Item
+	index := make(map[string]int)
+	for _, it := range items {
+		k := itemKey(it.Item)
+		if _, ok := index[k]; !ok {
+			index[k] = len(keys)
+			keys = append(keys, it.Item)
+		}
+	}
+
+	obsolete := make([]bool, len(keys))
+	errs, _ := runBatch(ctx, len(keys), bl.batchOptions(nil), func(ctx context.Context, i int) error {
+		item, err := bl.catalogItem(ctx, keys[i].Type, keys[i].No)
+		obsolete[i] = item.IsObsolete
+		return err
+	})
+
+	var merr MultiError
+	for i, err := range errs {
+		if err != nil {
+			merr = append(merr, fmt.Errorf("item %v %v: %w", keys[i].Type, keys[i].No, err))
+		}
+	}
+
+	for i := range parts {
+		parts[i].IsObsolete = obsolete[index[itemKey(parts[i].Item)]]
+	}
+
+	return parts, merr.errOrNil()
+}
+
+// subsetNode is an item expanded by GetSubsetsDeep
+type subsetNode struct {
+	item     Item
+	quantity int
+	// path holds the keys of the items the node is contained in
+	path []string
+}
+
+// GetSubsetsDeep expands the set into its parts like GetSetParts, but also
+// expands the sets and minifigs it contains, e.g. the sub-builds of modern
+// sets, up to the depth set with WithMaxDepth. Quantities are multiplied
+// along the way and items of the same color are merged.
+//
+// Each level is fetched concurrently, subject to the rate limit and served
+// from the cache if enabled. Nodes which can't be expanded, because their
+// request fails or they contain themselves, are kept as items and their
+// errors returned as MultiError. Only a failure of the set itself is
+// returned as plain error.
+func (bl Bricklink) GetSubsetsDeep(ctx context.Context, setNumber string, opts ...SubsetOption) ([]SubsetItem, error) {
+	o := subsetOptions{maxDepth: defaultMaxDepth}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	root, err := bl.subsetsURI("SET", setNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []SubsetItem
+	index := make(map[string]int)
+	addItem := func(e SubsetItem, multiplier int) {
+		k := itemKey(e.Item) + "/" + strconv.Itoa(e.ColorID)
+		e.Quantity *= multiplier
+		e.ExtraQuantity *= multiplier
+		if i, ok := index[k]; ok {
+			items[i].Quantity += e.Quantity
+			items[i].ExtraQuantity += e.ExtraQuantity
+			return
+		}
+		index[k] = len(items)
+		items = append(items, e)
+	}
+
+	var merr MultiError
+	frontier := []subsetNode{{item: Item{No: bl.itemNumber("SET", setNumber), Type: "SET"}, quantity: 1}}
+	for depth := 0; len(frontier) > 0; depth++ {
+		subsets := make([][]Subset, len(frontier))
+		errs, _ := runBatch(ctx, len(frontier), bl.batchOptions(nil), func(ctx context.Context, i int) error {
+			uri := root
+			if depth > 0 {
+				uri = buildURI("/items/"+frontier[i].item.Type+"/"+frontier[i].item.No+"/subsets", nil)
+			}
+			return bl.getParsed(ctx, uri, &subsets[i])
+		})
+		if depth == 0 && errs[0] != nil {
+			return nil, errs[0]
+		}
+
+		var next []subsetNode
+		for i, node := range frontier {
+			if errs[i] != nil {
+				merr = append(merr, fmt.Errorf("item %v %v: %w", node.item.Type, node.item.No, errs[i]))
+				addItem(SubsetItem{Item: node.item, Quantity: 1}, node.quantity)
+				continue
+			}
+
+			path := append(append([]string(nil), node.path...), itemKey(node.item))
+			for _, e := range flattenSubsets(subsets[i]) {
+				if !expandable(e.Item.Type) || depth+1 >= o.maxDepth {
+					addItem(e, node.quantity)
+					continue
+				}
+				if stringInSlice(itemKey(e.Item), path) {
+					merr = append(merr, fmt.Errorf("item %v %v: contains itself", e.Item.Type, e.Item.No))
+					addItem(e, node.quantity)
+					continue
+				}
+				next = append(next, subsetNode{item: e.Item, quantity: e.Quantity * node.quantity, path: path})
+			}
+		}
+		frontier = next
+	}
+
+	return items, merr.errOrNil()
+}
+
+// helper function to check if GetSubsetsDeep expands items of the type
+func expandable(itemType string) bool {
+	return strings.EqualFold(itemType, "SET") || strings.EqualFold(itemType, "MINIFIG")
+}
+
+// helper function to validate the params of a subsets request and build its uri
+func (bl Bricklink) subsetsURI(itemType, itemNumber string, params map[string]string) (uri string, err error) {
+	// validate itemType
+	err = bl.validParam(itemType, subsetItemTypes)
+	if err != nil {
+		return uri, err
+	}
+
+	// validate itemNumber
+	if itemNumber == "" {
+		return uri, errors.New("itemNumber is not specified")
+	}
+	itemNumber = bl.itemNumber(itemType, itemNumber)
+
+	return buildURI("/items/"+itemType+"/"+itemNumber+"/subsets", params), nil
+}
+
+// helper function to flatten subsets into their primary entries
+func flattenSubsets(subsets []Subset) []SubsetItem {
+	var items []SubsetItem
+	for _, s := range subsets {
+		for _, e := range s.Entries {
+			if e.IsAlternate || e.IsCounterpart {
+				continue
+			}
+			items = append(items, e)
+		}
+	}
+	return items
+}
@@ -0,0 +1,109 @@
+package bricklinkapi
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetMinifigParts(t *testing.T) {
+	f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[
+		{"entries":[{"item":{"no":"973","type":"PART"},"color_id":11,"quantity":1}]},
+		{"entries":[{"item":{"no":"3626b","type":"PART"},"color_id":3,"quantity":1},{"item":{"no":"3626c","type":"PART"},"color_id":3,"quantity":1,"is_alternate":true}]},
+		{"entries":[{"item":{"no":"970c00","type":"PART"},"color_id":11,"quantity":1,"is_counterpart":true}]}]}`)}
+	bl := New("", "", "", "")
+	bl.request = f
+
+	parts, err := bl.GetMinifigParts("sw0001")
+	if err != nil || len(parts) != 2 || parts[0].Item.No != "973" || parts[1].Item.No != "3626b" {
+		t.Errorf("\nwant: 973 and 3626b, got: %+v, %v\n", parts, err)
+	}
+	if f.uri != "/items/MINIFIG/sw0001/subsets" {
+		t.Errorf("\nunexpected request: %v\n", f.uri)
+	}
+
+	_, err = bl.GetMinifigParts("")
+	if err == nil {
+		t.Errorf("\nempty minifig number, want error, got nil\n")
+	}
+}
+
+func TestGetSetParts(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/items/SET/6020-1/subsets": `{"meta":{"code":200},"data":[
+			{"entries":[{"item":{"no":"3001","type":"PART"},"color_id":5,"quantity":4}]},
+			{"entries":[{"item":{"no":"3001","type":"PART"},"color_id":1,"quantity":1}]},
+			{"entries":[{"item":{"no":"3002","type":"PART"},"color_id":1,"quantity":2},{"item":{"no":"3003","type":"PART"},"color_id":1,"quantity":2,"is_alternate":true}]},
+			{"entries":[{"item":{"no":"3004","type":"PART"},"color_id":1,"quantity":1}]}]}`,
+		"/items/PART/3001": `{"meta":{"code":200},"data":{"no":"3001","type":"PART","is_obsolete":false}}`,
+		"/items/PART/3002": `{"meta":{"code":200},"data":{"no":"3002","type":"PART","is_obsolete":true}}`,
+	}
+
+	parts, err := bl.GetSetParts(context.Background(), "6020-1")
+	if err != nil || len(parts) != 4 {
+		t.Fatalf("\nwant: 4 parts, got: %v, %v\n", len(parts), err)
+	}
+
+	parts, err = bl.GetSetParts(context.Background(), "6020-1", WithObsoleteStatus())
+	merr, ok := err.(MultiError)
+	if !ok || len(merr) != 1 {
+		t.Errorf("\nwant: one error for 3004, got: %v\n", err)
+	}
+
+	want := []bool{false, false, true, false}
+	for i, p := range parts {
+		if p.IsObsolete != want[i] {
+			t.Errorf("\n%v: want obsolete: %v, got: %v\n", p.Item.No, want[i], p.IsObsolete)
+		}
+	}
+}
+
+func TestGetSubsetsDeep(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/items/SET/1000-1/subsets": `{"meta":{"code":200},"data":[
+			{"entries":[{"item":{"no":"3001","type":"PART"},"color_id":5,"quantity":2}]},
+			{"entries":[{"item":{"no":"2000-1","type":"SET"},"quantity":2}]},
+			{"entries":[{"item":{"no":"fig001","type":"MINIFIG"},"quantity":1}]},
+			{"entries":[{"item":{"no":"fig002","type":"MINIFIG"},"quantity":1}]}]}`,
+		"/items/SET/2000-1/subsets": `{"meta":{"code":200},"data":[
+			{"entries":[{"item":{"no":"3001","type":"PART"},"color_id":5,"quantity":3}]},
+			{"entries":[{"item":{"no":"1000-1","type":"SET"},"quantity":1}]}]}`,
+		"/items/MINIFIG/fig001/subsets": `{"meta":{"code":200},"data":[
+			{"entries":[{"item":{"no":"973","type":"PART"},"color_id":1,"quantity":1}]}]}`,
+	}
+
+	testCases := []struct {
+		desc  string
+		opts  []SubsetOption
+		items map[string]int
+		errs  int
+	}{
+		{desc: "testing deep", opts: nil, items: map[string]int{"3001": 8, "1000-1": 2, "973": 1, "fig002": 1}, errs: 2},
+		{desc: "testing flat", opts: []SubsetOption{WithMaxDepth(1)}, items: map[string]int{"3001": 2, "2000-1": 2, "fig001": 1, "fig002": 1}, errs: 0},
+	}
+	for _, tc := range testCases {
+		items, err := bl.GetSubsetsDeep(context.Background(), "1000-1", tc.opts...)
+		merr, _ := err.(MultiError)
+		if len(merr) != tc.errs || tc.errs == 0 && err != nil {
+			t.Errorf("%v, want: %v errors, got: %v\n", tc.desc, tc.errs, err)
+		}
+		result := make(map[string]int)
+		for _, it := range items {
+			result[it.Item.No] = it.Quantity
+		}
+		if len(result) != len(tc.items) {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.items, result)
+		}
+		for no, q := range tc.items {
+			if result[no] != q {
+				t.Errorf("%v, %v want quantity: %v, got: %v\n", tc.desc, no, q, result[no])
+			}
+		}
+	}
+
+	_, err := bl.GetSubsetsDeep(context.Background(), "9999-1")
+	if err == nil {
+		t.Errorf("\nexpected error for an unknown set\n")
+	}
+}
@@ -0,0 +1,176 @@
+package bricklinkapi
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StripHTML turns an HTML formatted description into plain text. Entities
+// are unescaped first, so encoded markup is stripped as well. Tags are
+// removed along with the contents of script and style elements, and line
+// breaks (<br>, paragraphs, list items) become newlines. A "<" only starts a
+// tag if followed by a letter, "/" or "!", otherwise it is kept as text. The
+// result is plain text: it must be escaped again before it is embedded into
+// HTML.
+func StripHTML(s string) string {
+	s = html.UnescapeString(s)
+
+	var b strings.Builder
+	for {
+		start := tagStart(s)
+		if start < 0 {
+			b.WriteString(s)
+			break
+		}
+		end := strings.IndexByte(s[start:], '>')
+		if end < 0 {
+			// an unterminated tag is text
+			b.WriteString(s)
+			break
+		}
+
+		b.WriteString(s[:start])
+		tag := s[start+1 : start+end]
+		s = s[start+end+1:]
+		if breaksLine(tag) {
+			b.WriteByte('\n')
+		}
+
+		// skip the contents of script and style elements up to their
+		// closing tag, which is removed in the next iteration
+		if name := tagName(tag); !strings.HasPrefix(tag, "/") && (name == "script" || name == "style") {
+			i := indexClosingTag(s, name)
+			if i < 0 {
+				break
+			}
+			s = s[i:]
+		}
+	}
+
+	// tidy up the whitespace of every line
+	lines := strings.Split(b.String(), "\n")
+	var out []string
+	for _, l := range lines {
+		l = strings.Join(strings.Fields(l), " ")
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// helper function to find the first "<" of s starting a tag, i.e. followed by
+// a letter, "/" or "!". It returns -1 if there is none.
+func tagStart(s string) int {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] != '<' {
+			continue
+		}
+		c := s[i+1]
+		if c == '/' || c == '!' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			return i
+		}
+	}
+	return -1
+}
+
+// helper function to find the closing tag of the element name in s,
+// ignoring case. It returns -1 if there is none.
+func indexClosingTag(s, name string) int {
+	for i := 0; i+2+len(name) <= len(s); i++ {
+		if s[i] == '<' && s[i+1] == '/' && strings.EqualFold(s[i+2:i+2+len(name)], name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// helper function to return the lower case element name of a tag
+func tagName(tag string) string {
+	fields := strings.Fields(tag)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(strings.Trim(fields[0], "/"))
+}
+
+// helper function to check if a tag ends a line
+func breaksLine(tag string) bool {
+	switch tagName(tag) {
+	case "br", "p", "div", "li", "tr":
+		return true
+	}
+	return false
+}
+
+// PlainDescription returns the description of the lot as plain text, see
+// StripHTML. The raw description is kept in Description.
+func (inv Inventory) PlainDescription() string {
+	return StripHTML(inv.Description)
+}
+
+// PlainDescription returns the description of the item as plain text, see
+// StripHTML. The raw description is kept in Description.
+func (item CatalogItem) PlainDescription() string {
+	return StripHTML(item.Description)
+}
+
+// transliterations maps typographic characters BrickLink tends to mangle to
+// their ASCII counterparts
+var transliterations = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "′", "'",
+	"“", `"`, "”", `"`, "„", `"`, "″", `"`,
+	"–", "-", "—", "-", "−", "-",
+	"…", "...",
+	"\u00a0", " ", "\u2009", " ", "\u200b", "",
+	"\r\n", "\n", "\r", "\n",
+)
+
+// validateText checks a free text field for characters BrickLink doesn't
+// store faithfully: invalid UTF-8 and control characters other than tab and
+// line breaks. A carriage return only counts as line break as part of "\r\n".
+func validateText(field, s string) error {
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size <= 1 {
+				return fmt.Errorf("%v contains invalid UTF-8 at byte %v", field, i)
+			}
+		}
+		if r == '\r' && strings.HasPrefix(s[i+1:], "\n") {
+			continue
+		}
+		if unicode.IsControl(r) && r != '\t' && r != '\n' {
+			return fmt.Errorf("%v contains control character %U at byte %v", field, r, i)
+		}
+	}
+	return nil
+}
+
+// normalizeText replaces typographic quotes, dashes and spaces with ASCII,
+// turns "\r\n" and lone carriage returns into newlines, and drops invalid
+// UTF-8 and control characters other than tab and newline.
+func normalizeText(s string) string {
+	s = transliterations.Replace(strings.ToValidUTF8(s, ""))
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// prepareText normalizes a free text field if enabled and validates it
+func (bl Bricklink) prepareText(field, s string) (string, error) {
+	if bl.normalizeText {
+		s = normalizeText(s)
+	}
+
+	if bl.skipValidation {
+		return s, nil
+	}
+	return s, validateText(field, s)
+}
@@ -0,0 +1,127 @@
+package bricklinkapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestStripHTML(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    string
+		expS string
+	}{
+		{desc: "testing plain text", s: "foo bar", expS: "foo bar"},
+		{desc: "testing tags", s: "<b>foo</b> <i>bar</i>", expS: "foo bar"},
+		{desc: "testing line breaks", s: "foo<br>bar<BR/>baz", expS: "foo\nbar\nbaz"},
+		{desc: "testing paragraphs", s: "<p>foo</p><p>bar</p>", expS: "foo\nbar"},
+		{desc: "testing entities", s: "Tom &amp; Jerry &lt;3", expS: "Tom & Jerry <3"},
+		{desc: "testing script", s: "<script>alert(1)</script>ok", expS: "ok"},
+		{desc: "testing style", s: "<STYLE type=\"text/css\">p { color: red }</Style>ok", expS: "ok"},
+		{desc: "testing unclosed script", s: "ok<script>alert(1)", expS: "ok"},
+		{desc: "testing encoded script", s: "&lt;script&gt;alert(1)&lt;/script&gt;ok", expS: "ok"},
+		{desc: "testing comment", s: "<!-- note -->ok", expS: "ok"},
+		{desc: "testing lone bracket", s: "1 < 2", expS: "1 < 2"},
+		{desc: "testing comparison", s: "a < b and c > d", expS: "a < b and c > d"},
+		{desc: "testing empty tag", s: "foo<>bar", expS: "foo<>bar"},
+	}
+	for _, tc := range testCases {
+		result := StripHTML(tc.s)
+		if result != tc.expS {
+			t.Errorf("%v \"%v\", want: %q, got: %q\n", tc.desc, tc.s, tc.expS, result)
+		}
+	}
+}
+
+func TestValidateText(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		s      string
+		expErr string
+	}{
+		{desc: "testing empty", s: "", expErr: ""},
+		{desc: "testing line breaks and tabs", s: "Brick 2 x 4\nwith tab\t", expErr: ""},
+		{desc: "testing typographic characters", s: "“used” – 50%", expErr: ""},
+		{desc: "testing control character", s: "bell\a", expErr: "description contains control character U+0007 at byte 4"},
+		{desc: "testing invalid UTF-8", s: "bad \xff", expErr: "description contains invalid UTF-8 at byte 4"},
+		{desc: "testing CRLF line break", s: "Brick\r\n2 x 4", expErr: ""},
+		{desc: "testing lone carriage return", s: "Brick\r2 x 4", expErr: "description contains control character U+000D at byte 5"},
+	}
+	for _, tc := range testCases {
+		err := validateText("description", tc.s)
+		if tc.expErr == "" && err != nil || tc.expErr != "" && (err == nil || err.Error() != tc.expErr) {
+			t.Errorf("%v, want error: %v, got: %v\n", tc.desc, tc.expErr, err)
+		}
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    string
+		expS string
+	}{
+		{desc: "testing plain text", s: "plain", expS: "plain"},
+		{desc: "testing typographic characters", s: "“used” – 50%…", expS: `"used" - 50%...`},
+		{desc: "testing control characters", s: "it’s\r\nnew\a\xff", expS: "it's\nnew"},
+		{desc: "testing lone carriage returns", s: "old\rmac\r\r\n", expS: "old\nmac\n\n"},
+	}
+	for _, tc := range testCases {
+		result := normalizeText(tc.s)
+		if result != tc.expS {
+			t.Errorf("%v, want: %q, got: %q\n", tc.desc, tc.expS, result)
+		}
+	}
+}
+
+func TestCreateInventoryText(t *testing.T) {
+	lot := InventoryCreate{Item: Item{No: "3001", Type: "PART"}, Quantity: 1, Remarks: "bin\x1b7"}
+
+	bl := New("", "", "", "")
+	f := &fakeRequest{body: []byte(`{"meta":{"code":201},"data":{"inventory_id":1}}`)}
+	bl.request = f
+	_, err := bl.CreateInventory(context.Background(), lot)
+	if err == nil || !strings.Contains(err.Error(), "remarks") {
+		t.Errorf("\nwant remarks error, got: %v\n", err)
+	}
+
+	bl = New("", "", "", "", WithTextNormalization())
+	bl.request = f
+	_, err = bl.CreateInventory(context.Background(), lot)
+	if err != nil || !strings.Contains(string(f.payload), `"remarks":"bin7"`) {
+		t.Errorf("\nunexpected error: %v, payload: %s\n", err, f.payload)
+	}
+}
+
+func TestUpdateInventoryText(t *testing.T) {
+	update := InventoryUpdate{InventoryID: 1, Description: "used\r", Remarks: "bin\x1b7"}
+
+	bl := New("", "", "", "")
+	f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":{"inventory_id":1}}`)}
+	bl.request = f
+	_, err := bl.UpdateInventory(context.Background(), update)
+	if err == nil || !strings.Contains(err.Error(), "description") {
+		t.Errorf("\nwant description error, got: %v\n", err)
+	}
+
+	bl = New("", "", "", "", WithTextNormalization())
+	bl.request = f
+	_, err = bl.UpdateInventory(context.Background(), update)
+	if err != nil || !strings.Contains(string(f.payload), `"description":"used\n","remarks":"bin7"`) {
+		t.Errorf("\nunexpected error: %v, payload: %s\n", err, f.payload)
+	}
+}
+
+func TestLeaveFeedbackText(t *testing.T) {
+	bl := New("", "", "", "")
+	f := &fakeRequest{body: []byte(`{"meta":{"code":200},"data":[]}`)}
+	bl.request = f
+	_, err := bl.LeaveFeedbackForCompleted(context.Background(), "thanks\a", RatingPraise)
+	if err == nil || !strings.Contains(err.Error(), "comment") {
+		t.Errorf("\nwant comment error, got: %v\n", err)
+	}
+	if f.calls != 0 {
+		t.Errorf("\nwant no request, got: %v\n", f.calls)
+	}
+}
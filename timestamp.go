@@ -0,0 +1,49 @@
+package bricklinkapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the layouts timestamps are parsed with, in order.
+// BrickLink mostly sends RFC3339 with milliseconds, but the zone or the
+// fraction are missing in some responses.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// timestamp is a time.Time decoded leniently from the formats BrickLink
+// uses. Empty strings and null decode to the zero time. Timestamps without
+// zone are taken as UTC.
+type timestamp time.Time
+
+func (ts *timestamp) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), "\"")
+	if s == "" || s == "null" {
+		*ts = timestamp{}
+		return nil
+	}
+
+	t, err := parseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*ts = timestamp(t)
+
+	return nil
+}
+
+// helper function to parse a timestamp in one of the timestampLayouts
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("timestamp \"%v\" is not valid", s)
+}
@@ -0,0 +1,95 @@
+package bricklinkapi
+
+import (
+	"strconv"
+)
+
+// Typed is the typed counterpart of the raw string methods of Bricklink:
+// methods of the same name return parsed structs, and errors reported by
+// BrickLink as *BrickLinkError. It is a thin wrapper, all requests are
+// issued by the wrapped handler with its options.
+type Typed struct {
+	bl *Bricklink
+}
+
+// Typed returns the typed methods of the handler. They share its cache and
+// rate limit, but fields of the handler changed afterwards are not seen.
+func (bl Bricklink) Typed() Typed {
+	return Typed{bl: &bl}
+}
+
+// GetItem querys for the specified catalog item.
+func (t Typed) GetItem(itemType, itemNumber string) (CatalogItem, error) {
+	return t.bl.GetItemParsed(itemType, itemNumber)
+}
+
+// GetItemPrice querys for the price guide of the specified item.
+func (t Typed) GetItemPrice(itemType, itemNumber string, opts PriceGuideOptions) (PriceGuide, error) {
+	return t.bl.GetPriceGuide(itemType, itemNumber, opts)
+}
+
+// GetSubsets querys for the items the specified item consists of.
+func (t Typed) GetSubsets(itemType, itemNumber string, params map[string]string) ([]Subset, error) {
+	return t.bl.GetSubsetsParsed(itemType, itemNumber, params)
+}
+
+// GetColorList querys for a list of all colors.
+func (t Typed) GetColorList() ([]Color, error) {
+	return t.bl.GetColorListParsed()
+}
+
+// GetColor querys for the specified color.
+func (t Typed) GetColor(colorID int) (color Color, err error) {
+	err = t.bl.getParsed(t.bl.context(), "/colors/"+strconv.Itoa(colorID), &color)
+	return color, err
+}
+
+// GetCategoryList querys for a list of all categories.
+func (t Typed) GetCategoryList() ([]Category, error) {
+	return t.bl.GetCategoryListParsed()
+}
+
+// GetCategory querys for the specified category.
+func (t Typed) GetCategory(categoryID int) (category Category, err error) {
+	err = t.bl.getParsed(t.bl.context(), "/categories/"+strconv.Itoa(categoryID), &category)
+	return category, err
+}
+
+// GetInventoryList querys for the store inventory, see GetInventoryList of
+// Bricklink for the params.
+func (t Typed) GetInventoryList(params map[string]string) ([]Inventory, error) {
+	return t.bl.GetInventoryListParsed(params)
+}
+
+// GetInventory querys for the specified inventory lot. It is the typed
+// counterpart of GetInventories, which despite its name takes an inventory
+// ID.
+func (t Typed) GetInventory(inventoryID int) (inventory Inventory, err error) {
+	err = t.bl.getParsed(t.bl.context(), "/inventories/"+strconv.Itoa(inventoryID), &inventory)
+	return inventory, err
+}
+
+// GetOrders querys for the orders received or placed.
+func (t Typed) GetOrders(params map[string]string) ([]Order, error) {
+	return t.bl.GetOrdersParsed(params)
+}
+
+// GetOrder querys for the specified order.
+func (t Typed) GetOrder(orderID int) (Order, error) {
+	return t.bl.GetOrderParsed(orderID)
+}
+
+// GetOrderItems querys for the items of the specified order, in batches.
+func (t Typed) GetOrderItems(orderID int) ([][]OrderItem, error) {
+	return t.bl.GetOrderItemsParsed(orderID)
+}
+
+// GetFeedbackList querys for the feedback received or left.
+func (t Typed) GetFeedbackList(params map[string]string) ([]Feedback, error) {
+	return t.bl.GetFeedbackListParsed(params)
+}
+
+// GetNotifications querys for the unread push notifications.
+func (t Typed) GetNotifications() ([]Notification, error) {
+	return t.bl.GetNotificationsParsed()
+}
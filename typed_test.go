@@ -0,0 +1,36 @@
+package bricklinkapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTyped(t *testing.T) {
+	bl := New("", "", "", "")
+	bl.request = routeRequest{
+		"/items/PART/3001": `{"meta":{"code":200},"data":{"no":"3001","type":"PART"}}`,
+		"/colors/5":        `{"meta":{"code":200},"data":{"color_id":5,"color_name":"Red"}}`,
+		"/inventories/7":   `{"meta":{"code":200},"data":{"inventory_id":7,"quantity":3}}`,
+		"/orders/1":        `{"meta":{"code":404,"message":"RESOURCE_NOT_FOUND"}}`,
+	}
+	typed := bl.Typed()
+
+	item, err := typed.GetItem("PART", "3001")
+	if err != nil || item.No != "3001" {
+		t.Errorf("\nunexpected item: %+v (%v)\n", item, err)
+	}
+	color, err := typed.GetColor(5)
+	if err != nil || color.ColorName != "Red" {
+		t.Errorf("\nunexpected color: %+v (%v)\n", color, err)
+	}
+	inv, err := typed.GetInventory(7)
+	if err != nil || inv.Quantity != 3 {
+		t.Errorf("\nunexpected inventory: %+v (%v)\n", inv, err)
+	}
+
+	_, err = typed.GetOrder(1)
+	var blErr *BrickLinkError
+	if !errors.As(err, &blErr) || blErr.Code != 404 {
+		t.Errorf("\nwant: *BrickLinkError 404, got: %v\n", err)
+	}
+}
@@ -0,0 +1,67 @@
+package bricklinkapi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StoreValue is the valuation of a number of inventory lots.
+type StoreValue struct {
+	Lots  int
+	Items int
+	// ListValue is the sum of unit price times quantity
+	ListValue Money
+	// Cost is the sum of my cost times quantity
+	Cost Money
+	// MarketValue is the sum of market price times quantity, for the lots
+	// a market price was given for
+	MarketValue Money
+}
+
+// GroupByCategory groups lots by the category ID of their item.
+func GroupByCategory(inv Inventory) string {
+	return strconv.Itoa(inv.Item.CategoryID)
+}
+
+// GroupByCondition groups lots by condition ("N" or "U").
+func GroupByCondition(inv Inventory) string {
+	return strings.ToUpper(inv.NewOrUsed)
+}
+
+// ValueStore computes the total value of the given lots and, if groupBy is
+// set, the value of each group. marketPrices optionally maps inventory IDs to
+// current unit prices (e.g. from the price guide); lots without an entry
+// don't contribute to MarketValue.
+func ValueStore(inventories []Inventory, groupBy func(Inventory) string, marketPrices map[int]Money) (total StoreValue, groups map[string]StoreValue) {
+	if groupBy != nil {
+		groups = make(map[string]StoreValue)
+	}
+
+	for _, inv := range inventories {
+		var v StoreValue
+		v.Lots = 1
+		v.Items = inv.Quantity
+		v.ListValue.Amount = inv.UnitPrice.Amount * int64(inv.Quantity)
+		v.Cost.Amount = inv.MyCost.Amount * int64(inv.Quantity)
+		if p, ok := marketPrices[inv.InventoryID]; ok {
+			v.MarketValue.Amount = p.Amount * int64(inv.Quantity)
+		}
+
+		total = total.add(v)
+		if groupBy != nil {
+			k := groupBy(inv)
+			groups[k] = groups[k].add(v)
+		}
+	}
+
+	return total, groups
+}
+
+func (s StoreValue) add(o StoreValue) StoreValue {
+	s.Lots += o.Lots
+	s.Items += o.Items
+	s.ListValue.Amount += o.ListValue.Amount
+	s.Cost.Amount += o.Cost.Amount
+	s.MarketValue.Amount += o.MarketValue.Amount
+	return s
+}
@@ -0,0 +1,27 @@
+package bricklinkapi
+
+import (
+	"testing"
+)
+
+func TestValueStore(t *testing.T) {
+	inventories := []Inventory{
+		{InventoryID: 1, NewOrUsed: "N", Quantity: 2, UnitPrice: Money{Amount: 10000}, MyCost: Money{Amount: 5000}},
+		{InventoryID: 2, NewOrUsed: "U", Quantity: 3, UnitPrice: Money{Amount: 2000}, MyCost: Money{Amount: 1000}},
+		{InventoryID: 3, NewOrUsed: "n", Quantity: 1, UnitPrice: Money{Amount: 500}},
+	}
+
+	total, groups := ValueStore(inventories, GroupByCondition, map[int]Money{1: {Amount: 12000}})
+	if total.Lots != 3 || total.Items != 6 {
+		t.Errorf("\nunexpected totals: %+v\n", total)
+	}
+	if total.ListValue.Amount != 26500 || total.Cost.Amount != 13000 || total.MarketValue.Amount != 24000 {
+		t.Errorf("\nunexpected values: %+v\n", total)
+	}
+	if groups["N"].Lots != 2 || groups["N"].ListValue.Amount != 20500 {
+		t.Errorf("\nunexpected group N: %+v\n", groups["N"])
+	}
+	if groups["U"].Lots != 1 || groups["U"].Cost.Amount != 3000 {
+		t.Errorf("\nunexpected group U: %+v\n", groups["U"])
+	}
+}
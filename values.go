@@ -0,0 +1,39 @@
+package bricklinkapi
+
+// The functions below expose the values the client accepts, e.g. for
+// building dropdowns. They return copies, so the slices can be modified
+// freely by the caller.
+
+// ItemTypes returns all valid item types.
+func ItemTypes() []string {
+	return copyStrings(itemTypes)
+}
+
+// OrderStatuses returns all valid order statuses.
+func OrderStatuses() []string {
+	return copyStrings(orderStatuses)
+}
+
+// PaymentStatuses returns all valid payment statuses.
+func PaymentStatuses() []string {
+	return copyStrings(paymentStatuses)
+}
+
+// OrderDirections returns all valid order directions ("in" for orders
+// received, "out" for orders placed).
+func OrderDirections() []string {
+	return copyStrings(orderDirections)
+}
+
+// CompletenessValues returns all valid completeness values of sets
+// ("C" complete, "B" incomplete, "S" sealed).
+func CompletenessValues() []string {
+	return copyStrings(completenessValues)
+}
+
+// helper function to copy a string slice
+func copyStrings(list []string) []string {
+	c := make([]string, len(list))
+	copy(c, list)
+	return c
+}
@@ -0,0 +1,32 @@
+package bricklinkapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		values func() []string
+		expS   string
+	}{
+		{desc: "testing item types", values: ItemTypes, expS: "MINIFIG,PART,SET,BOOK,GEAR,CATALOG,INSTRUCTION,UNSORTED_LOT,ORIGINAL_BOX"},
+		{desc: "testing order statuses", values: OrderStatuses, expS: "PENDING,UPDATED,PROCESSING,READY,PAID,PACKED,SHIPPED,RECEIVED,COMPLETED,OCR,NPB,NPX,NRS,NSS,CANCELLED,PURGED"},
+		{desc: "testing payment statuses", values: PaymentStatuses, expS: "None,Sent,Received,Clearing,Returned,Bounced,Completed"},
+		{desc: "testing order directions", values: OrderDirections, expS: "in,out"},
+		{desc: "testing completeness values", values: CompletenessValues, expS: "C,B,S"},
+	}
+	for _, tc := range testCases {
+		result := tc.values()
+		if strings.Join(result, ",") != tc.expS {
+			t.Errorf("%v, want: %v, got: %v\n", tc.desc, tc.expS, result)
+		}
+
+		// the caller gets a copy
+		result[0] = "foo"
+		if tc.values()[0] == "foo" {
+			t.Errorf("%v, modifying the result changed the valid values\n", tc.desc)
+		}
+	}
+}
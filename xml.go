@@ -0,0 +1,199 @@
+package bricklinkapi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// xmlItemTypes maps the item types to their codes in BrickLink's XML formats
+var xmlItemTypes = map[string]string{
+	"PART":         "P",
+	"SET":          "S",
+	"MINIFIG":      "M",
+	"BOOK":         "B",
+	"GEAR":         "G",
+	"CATALOG":      "C",
+	"INSTRUCTION":  "I",
+	"ORIGINAL_BOX": "O",
+	"UNSORTED_LOT": "U",
+}
+
+// xmlCompleteness maps the completeness values to their codes in the XML
+// formats, which use "I" for incomplete sets
+var xmlCompleteness = map[string]string{
+	"C": "C",
+	"B": "I",
+	"S": "S",
+}
+
+type xmlInventory struct {
+	XMLName xml.Name  `xml:"INVENTORY"`
+	Items   []xmlItem `xml:"ITEM"`
+}
+
+type xmlItem struct {
+	ItemType     string `xml:"ITEMTYPE"`
+	ItemID       string `xml:"ITEMID"`
+	Color        int    `xml:"COLOR"`
+	Price        string `xml:"PRICE"`
+	Qty          int    `xml:"QTY"`
+	Condition    string `xml:"CONDITION"`
+	SubCondition string `xml:"SUBCONDITION,omitempty"`
+	Description  string `xml:"DESCRIPTION,omitempty"`
+	Remarks      string `xml:"REMARKS,omitempty"`
+	Bulk         int    `xml:"BULK,omitempty"`
+	MyCost       string `xml:"MYCOST,omitempty"`
+	Stockroom    string `xml:"STOCKROOM,omitempty"`
+	Retain       string `xml:"RETAIN,omitempty"`
+}
+
+// ExportInventoryXML encodes the lots in BrickLink's Mass Upload XML format,
+// e.g. to move a store to another tool. An error is returned for lots
+// without item number or with an unknown item type or condition.
+func ExportInventoryXML(inventories []Inventory) ([]byte, error) {
+	doc := xmlInventory{Items: make([]xmlItem, 0, len(inventories))}
+	for _, inv := range inventories {
+		itemType, ok := xmlItemTypes[strings.ToUpper(inv.Item.Type)]
+		if !ok {
+			return nil, fmt.Errorf("lot %v: item type \"%v\" is not valid", inv.InventoryID, inv.Item.Type)
+		}
+		if inv.Item.No == "" {
+			return nil, fmt.Errorf("lot %v: item number is not specified", inv.InventoryID)
+		}
+		condition := strings.ToUpper(inv.NewOrUsed)
+		if condition != "N" && condition != "U" {
+			return nil, fmt.Errorf("lot %v: condition \"%v\" is not valid", inv.InventoryID, inv.NewOrUsed)
+		}
+
+		item := xmlItem{
+			ItemType:     itemType,
+			ItemID:       inv.Item.No,
+			Color:        inv.ColorID,
+			Price:        inv.UnitPrice.String(),
+			Qty:          inv.Quantity,
+			Condition:    condition,
+			SubCondition: xmlCompleteness[strings.ToUpper(inv.Completeness)],
+			Description:  inv.Description,
+			Remarks:      inv.Remarks,
+			Stockroom:    yesOrEmpty(inv.IsStockRoom),
+			Retain:       yesOrEmpty(inv.IsRetain),
+		}
+		if inv.Bulk > 1 {
+			item.Bulk = inv.Bulk
+		}
+		if inv.MyCost.Amount != 0 {
+			item.MyCost = inv.MyCost.String()
+		}
+		doc.Items = append(doc.Items, item)
+	}
+
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
+
+// helper function to encode a flag of the XML formats
+func yesOrEmpty(b bool) string {
+	if b {
+		return "Y"
+	}
+	return ""
+}
+
+// WantedItem is an entry of a BrickLink wanted list.
+type WantedItem struct {
+	Item    Item
+	ColorID int
+
+	// MinQty is the quantity wanted, QtyFilled the quantity already bought
+	MinQty    int
+	QtyFilled int
+
+	// MaxPrice is nil if no maximum price is set
+	MaxPrice *Money
+
+	// Condition is "N", "U" or empty for any condition
+	Condition    string
+	Remarks      string
+	Notify       bool
+	WantedListID int
+}
+
+type xmlWantedList struct {
+	Items []xmlWantedItem `xml:"ITEM"`
+}
+
+type xmlWantedItem struct {
+	ItemType     string `xml:"ITEMTYPE"`
+	ItemID       string `xml:"ITEMID"`
+	Color        int    `xml:"COLOR"`
+	MaxPrice     string `xml:"MAXPRICE"`
+	MinQty       int    `xml:"MINQTY"`
+	QtyFilled    int    `xml:"QTYFILLED"`
+	Condition    string `xml:"CONDITION"`
+	Remarks      string `xml:"REMARKS"`
+	Notify       string `xml:"NOTIFY"`
+	WantedListID int    `xml:"WANTEDLISTID"`
+}
+
+// ParseWantedListXML reads a wanted list in BrickLink's XML format, e.g. to
+// price the wanted items with the price guide helpers.
+func ParseWantedListXML(b []byte) ([]WantedItem, error) {
+	var doc xmlWantedList
+	err := xml.Unmarshal(b, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode wanted list: %v", err)
+	}
+
+	itemTypes := make(map[string]string, len(xmlItemTypes))
+	for t, code := range xmlItemTypes {
+		itemTypes[code] = t
+	}
+
+	items := make([]WantedItem, 0, len(doc.Items))
+	for i, x := range doc.Items {
+		itemType, ok := itemTypes[strings.ToUpper(strings.TrimSpace(x.ItemType))]
+		if !ok {
+			return nil, fmt.Errorf("item %v: item type \"%v\" is not valid", i, x.ItemType)
+		}
+		itemNo := strings.TrimSpace(x.ItemID)
+		if itemNo == "" {
+			return nil, fmt.Errorf("item %v: item number is not specified", i)
+		}
+
+		w := WantedItem{
+			Item:         Item{No: itemNo, Type: itemType},
+			ColorID:      x.Color,
+			MinQty:       x.MinQty,
+			QtyFilled:    x.QtyFilled,
+			Remarks:      x.Remarks,
+			Notify:       strings.EqualFold(strings.TrimSpace(x.Notify), "Y"),
+			WantedListID: x.WantedListID,
+		}
+
+		switch c := strings.ToUpper(strings.TrimSpace(x.Condition)); c {
+		case "N", "U":
+			w.Condition = c
+		case "", "X":
+		default:
+			return nil, fmt.Errorf("item %v: condition \"%v\" is not valid", i, x.Condition)
+		}
+
+		// a missing or negative maximum price means there is none
+		if p := strings.TrimSpace(x.MaxPrice); p != "" && !strings.HasPrefix(p, "-") {
+			m, err := ParseMoney(p, "")
+			if err != nil {
+				return nil, fmt.Errorf("item %v: %v", i, err)
+			}
+			w.MaxPrice = &m
+		}
+
+		items = append(items, w)
+	}
+
+	return items, nil
+}
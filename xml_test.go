@@ -0,0 +1,80 @@
+package bricklinkapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportInventoryXML(t *testing.T) {
+	inventories := []Inventory{
+		{InventoryID: 1, Item: Item{No: "3001", Type: "PART"}, ColorID: 5, Quantity: 2, NewOrUsed: "N",
+			UnitPrice: Money{Amount: 1000}, Remarks: "Box <A> & B", IsRetain: true},
+		{InventoryID: 2, Item: Item{No: "6020-1", Type: "SET"}, Quantity: 1, NewOrUsed: "U", Completeness: "B", Bulk: 1},
+	}
+
+	b, err := ExportInventoryXML(inventories)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<INVENTORY>
+  <ITEM>
+    <ITEMTYPE>P</ITEMTYPE>
+    <ITEMID>3001</ITEMID>
+    <COLOR>5</COLOR>
+    <PRICE>0.1000</PRICE>
+    <QTY>2</QTY>
+    <CONDITION>N</CONDITION>
+    <REMARKS>Box &lt;A&gt; &amp; B</REMARKS>
+    <RETAIN>Y</RETAIN>
+  </ITEM>
+  <ITEM>
+    <ITEMTYPE>S</ITEMTYPE>
+    <ITEMID>6020-1</ITEMID>
+    <COLOR>0</COLOR>
+    <PRICE>0.0000</PRICE>
+    <QTY>1</QTY>
+    <CONDITION>U</CONDITION>
+    <SUBCONDITION>I</SUBCONDITION>
+  </ITEM>
+</INVENTORY>`
+	if strings.TrimSpace(string(b)) != want {
+		t.Errorf("\nwant:\n%v\ngot:\n%v\n", want, string(b))
+	}
+
+	_, err = ExportInventoryXML([]Inventory{{Item: Item{No: "3001", Type: "FOO"}, NewOrUsed: "N"}})
+	if err == nil {
+		t.Errorf("\ninvalid item type, want error, got nil\n")
+	}
+}
+
+func TestParseWantedListXML(t *testing.T) {
+	b := []byte(`<INVENTORY>
+<ITEM><ITEMTYPE>P</ITEMTYPE><ITEMID>3001</ITEMID><COLOR>5</COLOR><MAXPRICE>0.25</MAXPRICE><MINQTY>10</MINQTY><CONDITION>N</CONDITION><NOTIFY>Y</NOTIFY></ITEM>
+<ITEM><ITEMTYPE>M</ITEMTYPE><ITEMID>sw0001a</ITEMID><MAXPRICE>-1.0000</MAXPRICE><CONDITION>X</CONDITION><REMARKS>a &amp; b</REMARKS></ITEM>
+</INVENTORY>`)
+
+	items, err := ParseWantedListXML(b)
+	if err != nil {
+		t.Fatalf("\nunexpected error: %v\n", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("\nwant: 2 items, got: %+v\n", items)
+	}
+
+	first := items[0]
+	if first.Item != (Item{No: "3001", Type: "PART"}) || first.ColorID != 5 || first.MinQty != 10 ||
+		first.Condition != "N" || !first.Notify || first.MaxPrice == nil || first.MaxPrice.Amount != 2500 {
+		t.Errorf("\nunexpected item: %+v\n", first)
+	}
+	second := items[1]
+	if second.Item.Type != "MINIFIG" || second.Condition != "" || second.MaxPrice != nil || second.Remarks != "a & b" {
+		t.Errorf("\nunexpected item: %+v\n", second)
+	}
+
+	_, err = ParseWantedListXML([]byte(`<INVENTORY><ITEM><ITEMTYPE>Z</ITEMTYPE><ITEMID>1</ITEMID></ITEM></INVENTORY>`))
+	if err == nil {
+		t.Errorf("\ninvalid item type, want error, got nil\n")
+	}
+}